@@ -0,0 +1,105 @@
+package passkit
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APNsURL is the Apple Push Notification service endpoint for pass updates. Wallet passes always use the production
+// environment.
+const APNsURL = "https://api.push.apple.com"
+
+// PushNotifier tells devices that a pass changed, so they download the new version from the web service.
+type PushNotifier interface {
+	Notify(ctx context.Context, passTypeIdentifier, pushToken string) error
+}
+
+// APNsError is the error returned by APNsNotifier when APNs rejects a notification.
+type APNsError struct {
+	StatusCode int
+	Reason     string
+}
+
+func (e *APNsError) Error() string {
+	return fmt.Sprintf("APNs: notification rejected with status %d: %s", e.StatusCode, e.Reason)
+}
+
+// Unregistered reports whether the push token is no longer valid, meaning the device removed the pass or the app.
+func (e *APNsError) Unregistered() bool {
+	return e.StatusCode == http.StatusGone || e.Reason == "BadDeviceToken" || e.Reason == "Unregistered"
+}
+
+// APNsNotifier sends pass update notifications through APNs over HTTP/2, authenticating with the pass type
+// certificate.
+type APNsNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewAPNsNotifier creates an APNsNotifier authenticated with the pass type certificate in info.
+func NewAPNsNotifier(info *SigningInformation) *APNsNotifier {
+	cert := tls.Certificate{
+		Certificate: [][]byte{info.signingCert.Raw},
+		PrivateKey:  info.privateKey,
+		Leaf:        info.signingCert,
+	}
+
+	return &APNsNotifier{
+		URL: APNsURL,
+		Client: &http.Client{
+			Timeout: 30 * time.Second,
+			Transport: &http.Transport{
+				TLSClientConfig:   &tls.Config{Certificates: []tls.Certificate{cert}},
+				ForceAttemptHTTP2: true,
+			},
+		},
+	}
+}
+
+// Notify sends the empty notification that makes the device with pushToken fetch the updated passes of
+// passTypeIdentifier.
+func (n *APNsNotifier) Notify(ctx context.Context, passTypeIdentifier, pushToken string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(n.URL, "/")+"/3/device/"+pushToken, strings.NewReader("{}"))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("apns-topic", passTypeIdentifier)
+	req.Header.Set("Content-Type", "application/json")
+
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	b, _ := io.ReadAll(resp.Body)
+	_ = json.Unmarshal(b, &body)
+
+	return &APNsError{StatusCode: resp.StatusCode, Reason: body.Reason}
+}
+
+// isUnregisteredPushToken reports whether err means the push token is no longer valid.
+func isUnregisteredPushToken(err error) bool {
+	var apnsErr *APNsError
+	return errors.As(err, &apnsErr) && apnsErr.Unregistered()
+}
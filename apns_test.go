@@ -0,0 +1,75 @@
+package passkit
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestAPNsServer(t *testing.T, handler http.HandlerFunc) (*httptest.Server, *APNsNotifier) {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(handler)
+	server.EnableHTTP2 = true
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	n := NewAPNsNotifier(newTestSigningInformation(t))
+	n.URL = server.URL
+	n.Client.Transport.(*http.Transport).TLSClientConfig.RootCAs = roots
+	return server, n
+}
+
+func TestAPNsNotifier_Notify(t *testing.T) {
+	_, n := newTestAPNsServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			t.Errorf("APNs requires HTTP/2, got %s", r.Proto)
+		}
+
+		if len(r.TLS.PeerCertificates) == 0 || r.TLS.PeerCertificates[0].Subject.CommonName != "Pass Type ID: pass.test" {
+			t.Errorf("the pass type certificate should be used as client certificate")
+		}
+
+		if r.Method != http.MethodPost || r.URL.Path != "/3/device/token123" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+
+		if r.Header.Get("apns-topic") != "pass.test" {
+			t.Errorf("the topic should be the pass type identifier, got %q", r.Header.Get("apns-topic"))
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "{}" {
+			t.Errorf("the payload should be empty, got %s", body)
+		}
+	})
+
+	if err := n.Notify(context.Background(), "pass.test", "token123"); err != nil {
+		t.Errorf("could not notify. %v", err)
+	}
+}
+
+func TestAPNsNotifier_Unregistered(t *testing.T) {
+	_, n := newTestAPNsServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte(`{"reason":"Unregistered"}`))
+	})
+
+	err := n.Notify(context.Background(), "pass.test", "token123")
+	apnsErr, ok := err.(*APNsError)
+	if !ok {
+		t.Fatalf("expected an APNsError, got %v", err)
+	}
+
+	if apnsErr.Reason != "Unregistered" || !apnsErr.Unregistered() {
+		t.Errorf("the token should be reported as unregistered, got %v", apnsErr)
+	}
+}
@@ -0,0 +1,341 @@
+package passkit
+
+import (
+	"fmt"
+	"time"
+)
+
+// PassBuilder builds a Pass with a fluent API. Problems found while building are collected and returned by Build
+// along with the validation errors of the finished pass, so they show up before the pass is signed:
+//
+//	pass, err := passkit.NewPassBuilder().
+//		Serial("1234").
+//		PassTypeIdentifier("pass.com.example").
+//		TeamIdentifier("TEAMID").
+//		OrganizationName("Example").
+//		Description("Concert ticket").
+//		EventTicket(func(b *passkit.FieldsBuilder) {
+//			b.Primary().Text("event", "Event", "The Concert")
+//			b.Secondary().Date("doors", "Doors open", doors, passkit.DateStyleMedium, passkit.DateStyleShort)
+//		}).
+//		Barcode(passkit.BarcodeFormatQR, "1234", "iso-8859-1").
+//		Build()
+type PassBuilder struct {
+	pass   *Pass
+	errors []string
+}
+
+// NewPassBuilder creates a builder for a pass with format version 1.
+func NewPassBuilder() *PassBuilder {
+	return &PassBuilder{pass: &Pass{FormatVersion: 1}}
+}
+
+func (b *PassBuilder) Serial(serialNumber string) *PassBuilder {
+	b.pass.SerialNumber = serialNumber
+	return b
+}
+
+func (b *PassBuilder) PassTypeIdentifier(passTypeIdentifier string) *PassBuilder {
+	b.pass.PassTypeIdentifier = passTypeIdentifier
+	return b
+}
+
+func (b *PassBuilder) TeamIdentifier(teamIdentifier string) *PassBuilder {
+	b.pass.TeamIdentifier = teamIdentifier
+	return b
+}
+
+func (b *PassBuilder) OrganizationName(organizationName string) *PassBuilder {
+	b.pass.OrganizationName = organizationName
+	return b
+}
+
+func (b *PassBuilder) Description(description string) *PassBuilder {
+	b.pass.Description = description
+	return b
+}
+
+func (b *PassBuilder) LogoText(logoText string) *PassBuilder {
+	b.pass.LogoText = logoText
+	return b
+}
+
+func (b *PassBuilder) GroupingIdentifier(groupingIdentifier string) *PassBuilder {
+	b.pass.GroupingIdentifier = groupingIdentifier
+	return b
+}
+
+// WebService sets the URL of the web service used to update the pass and the token the devices use to
+// authenticate with it.
+func (b *PassBuilder) WebService(webServiceURL, authenticationToken string) *PassBuilder {
+	b.pass.WebServiceURL = webServiceURL
+	b.pass.AuthenticationToken = authenticationToken
+	return b
+}
+
+// AllowHTTPWebService accepts a plain HTTP WebService URL. See Pass.SetAllowHTTPWebService.
+func (b *PassBuilder) AllowHTTPWebService() *PassBuilder {
+	b.pass.SetAllowHTTPWebService(true)
+	return b
+}
+
+func (b *PassBuilder) ForegroundColorHex(hex string) *PassBuilder {
+	if err := b.pass.SetForegroundColorHex(hex); err != nil {
+		b.errors = append(b.errors, fmt.Sprintf("PassBuilder: Invalid foreground color %q: %v", hex, err))
+	}
+	return b
+}
+
+func (b *PassBuilder) BackgroundColorHex(hex string) *PassBuilder {
+	if err := b.pass.SetBackgroundColorHex(hex); err != nil {
+		b.errors = append(b.errors, fmt.Sprintf("PassBuilder: Invalid background color %q: %v", hex, err))
+	}
+	return b
+}
+
+func (b *PassBuilder) LabelColorHex(hex string) *PassBuilder {
+	if err := b.pass.SetLabelColorHex(hex); err != nil {
+		b.errors = append(b.errors, fmt.Sprintf("PassBuilder: Invalid label color %q: %v", hex, err))
+	}
+	return b
+}
+
+func (b *PassBuilder) FooterBackgroundColorHex(hex string) *PassBuilder {
+	if err := b.pass.SetFooterBackgroundColorHex(hex); err != nil {
+		b.errors = append(b.errors, fmt.Sprintf("PassBuilder: Invalid footer background color %q: %v", hex, err))
+	}
+	return b
+}
+
+// Barcode adds a barcode to the pass. The first barcode that the device supports is displayed.
+func (b *PassBuilder) Barcode(format BarcodeFormat, message, messageEncoding string) *PassBuilder {
+	b.pass.Barcodes = append(b.pass.Barcodes, Barcode{Format: format, Message: message, MessageEncoding: messageEncoding})
+	return b
+}
+
+func (b *PassBuilder) Location(location Location) *PassBuilder {
+	b.pass.Locations = append(b.pass.Locations, location)
+	return b
+}
+
+func (b *PassBuilder) Beacon(beacon Beacon) *PassBuilder {
+	b.pass.Beacons = append(b.pass.Beacons, beacon)
+	return b
+}
+
+func (b *PassBuilder) RelevantDate(date PassRelevantDate) *PassBuilder {
+	b.pass.RelevantDates = append(b.pass.RelevantDates, date)
+	return b
+}
+
+func (b *PassBuilder) ExpirationDate(t time.Time) *PassBuilder {
+	b.pass.ExpirationDate = &t
+	return b
+}
+
+// AppLaunchURL sets the URL passed to the associated app when launching it from the pass. At least one store
+// identifier is required.
+func (b *PassBuilder) AppLaunchURL(appLaunchURL string, associatedStoreIdentifiers ...int64) *PassBuilder {
+	b.pass.AppLaunchURL = appLaunchURL
+	b.pass.AssociatedStoreIdentifiers = append(b.pass.AssociatedStoreIdentifiers, associatedStoreIdentifiers...)
+	return b
+}
+
+// AssociatedApp associates an Android app with the pass, launched with the AppLaunchURL by Android wallet apps.
+func (b *PassBuilder) AssociatedApp(app PWAssociatedApp) *PassBuilder {
+	b.pass.AddAssociatedApp(app)
+	return b
+}
+
+func (b *PassBuilder) UserInfo(key string, value interface{}) *PassBuilder {
+	if b.pass.UserInfo == nil {
+		b.pass.UserInfo = make(map[string]interface{})
+	}
+	b.pass.UserInfo[key] = value
+	return b
+}
+
+func (b *PassBuilder) Semantics(semantics *SemanticTag) *PassBuilder {
+	b.pass.Semantics = semantics
+	return b
+}
+
+func (b *PassBuilder) SharingProhibited() *PassBuilder {
+	b.pass.SharingProhibited = true
+	return b
+}
+
+func (b *PassBuilder) Voided() *PassBuilder {
+	b.pass.Voided = true
+	return b
+}
+
+// EventTicket makes the pass an event ticket, with the fields added by fn.
+func (b *PassBuilder) EventTicket(fn func(b *FieldsBuilder)) *PassBuilder {
+	t := NewEventTicket()
+	b.fields(t.GenericPass, fn)
+	b.pass.EventTicket = t
+	return b
+}
+
+// BoardingPass makes the pass a boarding pass for transitType, with the fields added by fn.
+func (b *PassBuilder) BoardingPass(transitType TransitType, fn func(b *FieldsBuilder)) *PassBuilder {
+	bp := NewBoardingPass(transitType)
+	b.fields(bp.GenericPass, fn)
+	b.pass.BoardingPass = bp
+	return b
+}
+
+// Coupon makes the pass a coupon, with the fields added by fn.
+func (b *PassBuilder) Coupon(fn func(b *FieldsBuilder)) *PassBuilder {
+	c := NewCoupon()
+	b.fields(c.GenericPass, fn)
+	b.pass.Coupon = c
+	return b
+}
+
+// StoreCard makes the pass a store card, with the fields added by fn.
+func (b *PassBuilder) StoreCard(fn func(b *FieldsBuilder)) *PassBuilder {
+	s := NewStoreCard()
+	b.fields(s.GenericPass, fn)
+	b.pass.StoreCard = s
+	return b
+}
+
+// Generic makes the pass a generic pass, with the fields added by fn.
+func (b *PassBuilder) Generic(fn func(b *FieldsBuilder)) *PassBuilder {
+	g := NewGenericPass()
+	b.fields(g, fn)
+	b.pass.Generic = g
+	return b
+}
+
+func (b *PassBuilder) fields(gp *GenericPass, fn func(b *FieldsBuilder)) {
+	if fn == nil {
+		return
+	}
+
+	fb := &FieldsBuilder{pass: gp}
+	fn(fb)
+	b.errors = append(b.errors, fb.errors...)
+}
+
+// Build validates the pass and returns it. If anything is wrong the returned error is a *ValidationError holding
+// every problem found.
+func (b *PassBuilder) Build() (*Pass, error) {
+	var validationErrors []string
+	validationErrors = append(validationErrors, b.errors...)
+	validationErrors = append(validationErrors, duplicateFieldKeyErrors(b.pass)...)
+	validationErrors = append(validationErrors, b.pass.GetValidationErrors()...)
+
+	if len(validationErrors) > 0 {
+		return nil, &ValidationError{Errors: validationErrors}
+	}
+
+	return b.pass.Clone(), nil
+}
+
+// duplicateFieldKeyErrors checks that every field key is unique within the pass.
+func duplicateFieldKeyErrors(p *Pass) []string {
+	gp := p.genericPass()
+	if gp == nil {
+		return nil
+	}
+
+	var validationErrors []string
+	seen := make(map[string]bool)
+	for _, fields := range [][]Field{gp.HeaderFields, gp.PrimaryFields, gp.SecondaryFields, gp.AuxiliaryFields, gp.BackFields, gp.AdditionalInfoFields} {
+		for _, f := range fields {
+			if seen[f.Key] {
+				validationErrors = append(validationErrors, fmt.Sprintf("Pass: Field key %q is used more than once", f.Key))
+			}
+			seen[f.Key] = true
+		}
+	}
+
+	return validationErrors
+}
+
+// FieldsBuilder adds fields to the sections of a pass style.
+type FieldsBuilder struct {
+	pass   *GenericPass
+	errors []string
+}
+
+func (b *FieldsBuilder) Header() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.HeaderFields}
+}
+
+func (b *FieldsBuilder) Primary() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.PrimaryFields}
+}
+
+func (b *FieldsBuilder) Secondary() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.SecondaryFields}
+}
+
+func (b *FieldsBuilder) Auxiliary() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.AuxiliaryFields}
+}
+
+func (b *FieldsBuilder) Back() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.BackFields}
+}
+
+func (b *FieldsBuilder) AdditionalInfo() *FieldSection {
+	return &FieldSection{builder: b, fields: &b.pass.AdditionalInfoFields}
+}
+
+// FieldSection adds fields to one of the field lists of a pass style.
+type FieldSection struct {
+	builder *FieldsBuilder
+	fields  *[]Field
+}
+
+// Add adds the field as is.
+func (s *FieldSection) Add(field Field) *FieldSection {
+	*s.fields = append(*s.fields, field)
+	return s
+}
+
+// Text adds a field displaying value as plain text.
+func (s *FieldSection) Text(key, label, value string) *FieldSection {
+	return s.Add(NewTextField(key, label, value))
+}
+
+// Date adds a field displaying t as a date, a time or both, depending on the styles. See NewDateField.
+func (s *FieldSection) Date(key, label string, t time.Time, dateStyle, timeStyle DateStyle) *FieldSection {
+	return s.Add(NewDateField(key, label, t, dateStyle, timeStyle))
+}
+
+// Currency adds a field displaying the decimal amount in the currency with the ISO 4217 currencyCode. See
+// NewCurrencyField.
+func (s *FieldSection) Currency(key, label, amount, currencyCode string) *FieldSection {
+	f, err := NewCurrencyField(key, label, amount, currencyCode)
+	if err != nil {
+		s.builder.errors = append(s.builder.errors, fmt.Sprintf("FieldsBuilder: %v", err))
+		return s
+	}
+
+	return s.Add(f)
+}
+
+// Number adds a field displaying value with the given number style.
+func (s *FieldSection) Number(key, label string, value float64, style NumberStyle) *FieldSection {
+	if string(style) == "" {
+		s.builder.errors = append(s.builder.errors, fmt.Sprintf("FieldsBuilder: Number field %q needs a NumberStyle", key))
+	}
+
+	return s.Add(NewNumberField(key, label, value, style))
+}
+
+// Attributed adds a field whose attributedValue is the given HTML. See NewAttributedField.
+func (s *FieldSection) Attributed(key, label, htmlValue string) *FieldSection {
+	f, err := NewAttributedField(key, label, htmlValue)
+	if err != nil {
+		s.builder.errors = append(s.builder.errors, fmt.Sprintf("FieldsBuilder: %v", err))
+		return s
+	}
+
+	return s.Add(f)
+}
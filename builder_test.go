@@ -0,0 +1,111 @@
+package passkit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func getBasicPassBuilder() *PassBuilder {
+	return NewPassBuilder().
+		Serial("1234").
+		PassTypeIdentifier("pass.com.example").
+		TeamIdentifier("TEAM1").
+		OrganizationName("Org").
+		Description("test").
+		Barcode(BarcodeFormatQR, "1234", "iso-8859-1")
+}
+
+func TestPassBuilder_Build(t *testing.T) {
+	doors := time.Date(2025, time.June, 19, 18, 30, 0, 0, time.UTC)
+
+	p, err := getBasicPassBuilder().
+		ForegroundColorHex("#ffffff").
+		EventTicket(func(b *FieldsBuilder) {
+			b.Primary().Text("event", "Event", "The Concert")
+			b.Secondary().
+				Date("doors", "Doors open", doors, DateStyleMedium, DateStyleShort).
+				Currency("price", "Price", "25.50", "EUR")
+			b.Back().Number("attendees", "Attendees", 3, NumberStyleSpellOut)
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Pass should be built. %v", err)
+	}
+
+	if p.FormatVersion != 1 || p.EventTicket == nil || p.ForegroundColor != "rgb(255,255,255)" {
+		t.Errorf("Pass was not built as expected. %+v", p)
+	}
+
+	if len(p.EventTicket.PrimaryFields) != 1 || len(p.EventTicket.SecondaryFields) != 2 || len(p.EventTicket.BackFields) != 1 {
+		t.Fatalf("Fields were not added to the right sections. %+v", p.EventTicket.GenericPass)
+	}
+
+	date := p.EventTicket.SecondaryFields[0]
+	if date.DateStyle != DateStyleMedium || date.TimeStyle != DateStyleShort || date.Value != doors {
+		t.Errorf("Date field was not built as expected. %+v", date)
+	}
+
+	price := p.EventTicket.SecondaryFields[1]
+	if price.CurrencyCode != "EUR" || price.NumberStyle != "" || price.Value != json.Number("25.50") {
+		t.Errorf("Currency field was not built as expected. %+v", price)
+	}
+
+	if p.EventTicket.BackFields[0].NumberStyle != NumberStyleSpellOut {
+		t.Errorf("Number field was not built as expected. %+v", p.EventTicket.BackFields[0])
+	}
+}
+
+func TestPassBuilder_InvalidPass(t *testing.T) {
+	_, err := NewPassBuilder().Serial("1234").Build()
+	if err == nil {
+		t.Fatalf("Pass should be invalid")
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Error should be a ValidationError. Have: %T", err)
+	}
+
+	if len(validationErr.Errors) != 2 {
+		t.Errorf("Pass should have two errors. Have: %v", validationErr.Errors)
+	}
+}
+
+func TestPassBuilder_InvalidFields(t *testing.T) {
+	_, err := getBasicPassBuilder().
+		BackgroundColorHex("not a color").
+		Generic(func(b *FieldsBuilder) {
+			b.Primary().Attributed("link", "Link", "<b>bold</b>")
+			b.Secondary().Currency("price", "Price", "1", "euro")
+			b.Auxiliary().Number("count", "Count", 1, "")
+			b.Back().Text("count", "Count", "duplicated")
+		}).
+		Build()
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Error should be a ValidationError. Have: %v", err)
+	}
+
+	if len(validationErr.Errors) != 5 {
+		t.Errorf("Pass should have five errors. Have: %v", validationErr.Errors)
+	}
+}
+
+func TestPassBuilder_BuildReturnsCopy(t *testing.T) {
+	b := getBasicPassBuilder().StoreCard(func(b *FieldsBuilder) {
+		b.Primary().Text("points", "Points", "100")
+	})
+
+	p, err := b.Build()
+	if err != nil {
+		t.Fatalf("Pass should be built. %v", err)
+	}
+
+	b.Serial("5678")
+	if p.SerialNumber != "1234" {
+		t.Errorf("Changing the builder should not change a built pass")
+	}
+}
@@ -0,0 +1,79 @@
+package passkit
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+)
+
+// createManifest returns the manifest.json of a bundle, mapping the name of every file to its hex encoded hash.
+func createManifest(files map[string][]byte, h crypto.Hash) ([]byte, error) {
+	if err := checkHashAlgorithm("manifest hash", h); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]string, len(files))
+	for name, data := range files {
+		// Skip .DS_Store files.
+		if name == ".DS_Store" {
+			continue
+		}
+
+		hh := h.New()
+		hh.Write(data)
+		m[name] = fmt.Sprintf("%x", hh.Sum(nil))
+	}
+
+	return json.Marshal(m)
+}
+
+// signBundleFiles adds the manifest of the files and its signature to the files.
+func signBundleFiles(files map[string][]byte, i *SigningInformation, o signerOptions) error {
+	mfst, err := createManifest(files, o.manifestHash)
+	if err != nil {
+		return err
+	}
+
+	signedMfst, err := signManifestFile(mfst, i, o)
+	if err != nil {
+		return err
+	}
+
+	files[manifestJsonFileName] = mfst
+	files[signatureFileName] = signedMfst
+	return nil
+}
+
+// createZipArchive zips the files, using the map keys as the names of the archive entries.
+func createZipArchive(files map[string][]byte) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+
+	for name, data := range files {
+		f, err := w.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		_, err = f.Write(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func copyFiles(files map[string][]byte) map[string][]byte {
+	filesCopy := make(map[string][]byte, len(files))
+	for k := range files {
+		filesCopy[k] = files[k]
+	}
+
+	return filesCopy
+}
@@ -0,0 +1,394 @@
+package passkit
+
+import (
+	"slices"
+	"time"
+)
+
+// Clone returns a deep copy of the pass. The pass styles, field lists, semantic tags, dates and UserInfo are
+// copied, so the returned pass can be modified without affecting the original.
+func (p *Pass) Clone() *Pass {
+	if p == nil {
+		return nil
+	}
+
+	c := *p
+	c.Beacons = cloneEach(p.Beacons, (*Beacon).Clone)
+	c.Locations = cloneEach(p.Locations, (*Location).Clone)
+	c.Barcodes = cloneEach(p.Barcodes, (*Barcode).Clone)
+	c.EventTicket = p.EventTicket.Clone()
+	c.Coupon = p.Coupon.Clone()
+	c.StoreCard = p.StoreCard.Clone()
+	c.BoardingPass = p.BoardingPass.Clone()
+	c.Generic = p.Generic.Clone()
+	c.AssociatedStoreIdentifiers = slices.Clone(p.AssociatedStoreIdentifiers)
+	c.UserInfo = cloneUserInfo(p.UserInfo)
+	c.RelevantDate = cloneTime(p.RelevantDate)
+	c.ExpirationDate = cloneTime(p.ExpirationDate)
+	c.RelevantDates = cloneEach(p.RelevantDates, (*PassRelevantDate).Clone)
+	c.Nfc = p.Nfc.Clone()
+	c.Semantics = p.Semantics.Clone()
+	c.EventDetail = p.EventDetail.Clone()
+	c.VenueDetail = p.VenueDetail.Clone()
+	c.TicketDetail = p.TicketDetail.Clone()
+	c.PreferredStyleSchemes = slices.Clone(p.PreferredStyleSchemes)
+	c.UpcomingPassInformation = cloneEach(p.UpcomingPassInformation, (*UpcomingPass).Clone)
+	c.AssociatedApps = cloneEach(p.AssociatedApps, (*PWAssociatedApp).Clone)
+
+	return &c
+}
+
+// Clone returns a deep copy of the field lists.
+func (gp *GenericPass) Clone() *GenericPass {
+	if gp == nil {
+		return nil
+	}
+
+	return &GenericPass{
+		HeaderFields:         cloneEach(gp.HeaderFields, (*Field).Clone),
+		PrimaryFields:        cloneEach(gp.PrimaryFields, (*Field).Clone),
+		SecondaryFields:      cloneEach(gp.SecondaryFields, (*Field).Clone),
+		AuxiliaryFields:      cloneEach(gp.AuxiliaryFields, (*Field).Clone),
+		BackFields:           cloneEach(gp.BackFields, (*Field).Clone),
+		AdditionalInfoFields: cloneEach(gp.AdditionalInfoFields, (*Field).Clone),
+	}
+}
+
+// Clone returns a deep copy of the boarding pass.
+func (b *BoardingPass) Clone() *BoardingPass {
+	if b == nil {
+		return nil
+	}
+
+	return &BoardingPass{GenericPass: b.GenericPass.Clone(), TransitType: b.TransitType}
+}
+
+// Clone returns a deep copy of the coupon.
+func (c *Coupon) Clone() *Coupon {
+	if c == nil {
+		return nil
+	}
+
+	return &Coupon{GenericPass: c.GenericPass.Clone()}
+}
+
+// Clone returns a deep copy of the event ticket.
+func (e *EventTicket) Clone() *EventTicket {
+	if e == nil {
+		return nil
+	}
+
+	return &EventTicket{GenericPass: e.GenericPass.Clone()}
+}
+
+// Clone returns a deep copy of the store card.
+func (s *StoreCard) Clone() *StoreCard {
+	if s == nil {
+		return nil
+	}
+
+	return &StoreCard{GenericPass: s.GenericPass.Clone()}
+}
+
+// Clone returns a deep copy of the event detail.
+func (e *EventDetail) Clone() *EventDetail {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+	c.EventStartDate = cloneTime(e.EventStartDate)
+	c.EventEndDate = cloneTime(e.EventEndDate)
+	c.EventLocation = e.EventLocation.Clone()
+
+	return &c
+}
+
+// Clone returns a deep copy of the venue detail.
+func (v *VenueDetail) Clone() *VenueDetail {
+	if v == nil {
+		return nil
+	}
+
+	c := *v
+	c.VenueLocation = v.VenueLocation.Clone()
+
+	return &c
+}
+
+// Clone returns a copy of the ticket detail.
+func (t *TicketDetail) Clone() *TicketDetail {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	return &c
+}
+
+// Clone returns a deep copy of the field, including its value and semantic tags.
+func (f *Field) Clone() *Field {
+	if f == nil {
+		return nil
+	}
+
+	c := *f
+	c.Value = cloneValue(f.Value)
+	c.AttributedValue = cloneValue(f.AttributedValue)
+	c.DataDetectorTypes = slices.Clone(f.DataDetectorTypes)
+	c.Semantics = f.Semantics.Clone()
+
+	return &c
+}
+
+// Clone returns a copy of the beacon.
+func (b *Beacon) Clone() *Beacon {
+	if b == nil {
+		return nil
+	}
+
+	c := *b
+	return &c
+}
+
+// Clone returns a copy of the location.
+func (l *Location) Clone() *Location {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	return &c
+}
+
+// Clone returns a copy of the barcode.
+func (b *Barcode) Clone() *Barcode {
+	if b == nil {
+		return nil
+	}
+
+	c := *b
+	return &c
+}
+
+// Clone returns a copy of the associated app.
+func (a *PWAssociatedApp) Clone() *PWAssociatedApp {
+	if a == nil {
+		return nil
+	}
+
+	c := *a
+	return &c
+}
+
+// Clone returns a copy of the NFC payload.
+func (n *NFC) Clone() *NFC {
+	if n == nil {
+		return nil
+	}
+
+	c := *n
+	return &c
+}
+
+// Clone returns a deep copy of the personalization dictionary.
+func (pz *Personalization) Clone() *Personalization {
+	if pz == nil {
+		return nil
+	}
+
+	c := *pz
+	c.RequiredPersonalizationFields = slices.Clone(pz.RequiredPersonalizationFields)
+
+	return &c
+}
+
+// Clone returns a deep copy of the relevant date.
+func (prd *PassRelevantDate) Clone() *PassRelevantDate {
+	if prd == nil {
+		return nil
+	}
+
+	return &PassRelevantDate{
+		Date:      cloneTime(prd.Date),
+		StartDate: cloneTime(prd.StartDate),
+		EndDate:   cloneTime(prd.EndDate),
+	}
+}
+
+// Clone returns a deep copy of the upcoming pass information entry.
+func (u *UpcomingPass) Clone() *UpcomingPass {
+	if u == nil {
+		return nil
+	}
+
+	c := *u
+	c.DateInformation = u.DateInformation.Clone()
+
+	return &c
+}
+
+// Clone returns a deep copy of the upcoming pass date information.
+func (u *UpcomingPassDateInformation) Clone() *UpcomingPassDateInformation {
+	if u == nil {
+		return nil
+	}
+
+	c := *u
+	c.Date = cloneTime(u.Date)
+
+	return &c
+}
+
+// Clone returns a deep copy of the semantic tags.
+func (s *SemanticTag) Clone() *SemanticTag {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.AlbumIDs = slices.Clone(s.AlbumIDs)
+	c.ArtistIds = slices.Clone(s.ArtistIds)
+	c.Balance = s.Balance.Clone()
+	c.CurrentArrivalDate = cloneTime(s.CurrentArrivalDate)
+	c.CurrentBoardingDate = cloneTime(s.CurrentBoardingDate)
+	c.CurrentDepartureDate = cloneTime(s.CurrentDepartureDate)
+	c.DepartureLocation = s.DepartureLocation.Clone()
+	c.DestinationLocation = s.DestinationLocation.Clone()
+	if s.Duration != nil {
+		d := *s.Duration
+		c.Duration = &d
+	}
+	c.EventEndDate = cloneTime(s.EventEndDate)
+	c.EventStartDate = cloneTime(s.EventStartDate)
+	c.EventStartDateInfo = s.EventStartDateInfo.Clone()
+	c.OriginalArrivalDate = cloneTime(s.OriginalArrivalDate)
+	c.OriginalBoardingDate = cloneTime(s.OriginalBoardingDate)
+	c.OriginalDepartureDate = cloneTime(s.OriginalDepartureDate)
+	c.PassengerName = s.PassengerName.Clone()
+	c.PerformerNames = slices.Clone(s.PerformerNames)
+	c.PlaylistIDs = slices.Clone(s.PlaylistIDs)
+	c.Seats = slices.Clone(s.Seats)
+	c.TotalPrice = s.TotalPrice.Clone()
+	c.VenueBoxOfficeOpenDate = cloneTime(s.VenueBoxOfficeOpenDate)
+	c.VenueCloseDate = cloneTime(s.VenueCloseDate)
+	c.VenueDoorsOpenDate = cloneTime(s.VenueDoorsOpenDate)
+	c.VenueFanZoneOpenDate = cloneTime(s.VenueFanZoneOpenDate)
+	c.VenueGatesOpenDate = cloneTime(s.VenueGatesOpenDate)
+	c.VenueLocation = s.VenueLocation.Clone()
+	c.VenueOpenDate = cloneTime(s.VenueOpenDate)
+	c.VenueParkingLotsOpenDate = cloneTime(s.VenueParkingLotsOpenDate)
+	c.RelevantDates = cloneEach(s.RelevantDates, (*PassRelevantDate).Clone)
+	c.WifiAccess = slices.Clone(s.WifiAccess)
+
+	return &c
+}
+
+// Clone returns a deep copy of the event date information.
+func (s *SemanticTagEventDateInfo) Clone() *SemanticTagEventDateInfo {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	c.Date = cloneTime(s.Date)
+	c.OriginalDate = cloneTime(s.OriginalDate)
+
+	return &c
+}
+
+// Clone returns a copy of the currency amount.
+func (s *SemanticTagCurrencyAmount) Clone() *SemanticTagCurrencyAmount {
+	if s == nil {
+		return nil
+	}
+
+	c := *s
+	return &c
+}
+
+// Clone returns a copy of the location.
+func (l *SemanticTagLocation) Clone() *SemanticTagLocation {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	return &c
+}
+
+// Clone returns a copy of the person name components.
+func (l *SemanticTagPersonNameComponents) Clone() *SemanticTagPersonNameComponents {
+	if l == nil {
+		return nil
+	}
+
+	c := *l
+	return &c
+}
+
+// cloneEach deep copies every element of s using the element's Clone method.
+func cloneEach[T any](s []T, clone func(*T) *T) []T {
+	if s == nil {
+		return nil
+	}
+
+	ret := make([]T, len(s))
+	for i := range s {
+		ret[i] = *clone(&s[i])
+	}
+
+	return ret
+}
+
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	return &c
+}
+
+func cloneUserInfo(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+
+	ret := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		ret[k] = cloneValue(v)
+	}
+
+	return ret
+}
+
+// cloneValue deep copies the values that can be stored in Field values and UserInfo. Maps, slices and pointers
+// are copied; anything else is returned as is.
+func cloneValue(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return cloneUserInfo(t)
+	case []interface{}:
+		if t == nil {
+			return t
+		}
+		ret := make([]interface{}, len(t))
+		for i := range t {
+			ret[i] = cloneValue(t[i])
+		}
+		return ret
+	case []string:
+		return slices.Clone(t)
+	case *time.Time:
+		return cloneTime(t)
+	case *string:
+		if t == nil {
+			return t
+		}
+		s := *t
+		return &s
+	default:
+		return v
+	}
+}
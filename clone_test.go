@@ -0,0 +1,150 @@
+package passkit
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func getFullPass() *Pass {
+	now := time.Now()
+	later := now.Add(time.Hour)
+	duration := uint64(3600)
+	f := getBasicField()
+	f.Value = "value"
+	f.Semantics = &SemanticTag{EventStartDate: &now}
+
+	p := getBasicPass()
+	p.Generic = nil
+	p.EventTicket = &EventTicket{GenericPass: &GenericPass{
+		HeaderFields:         []Field{f},
+		PrimaryFields:        []Field{f},
+		SecondaryFields:      []Field{f},
+		AuxiliaryFields:      []Field{f},
+		BackFields:           []Field{f},
+		AdditionalInfoFields: []Field{f},
+	}}
+	p.Beacons = []Beacon{getBasicBeacon()}
+	p.Locations = []Location{getBasicLocation()}
+	p.UserInfo = map[string]interface{}{"nested": map[string]interface{}{"list": []interface{}{"a", "b"}}}
+	p.RelevantDate = &now
+	p.RelevantDates = []PassRelevantDate{{StartDate: &now, EndDate: &later}}
+	p.Nfc = &NFC{Message: "message"}
+	p.Semantics = &SemanticTag{
+		AlbumIDs:            []string{"1"},
+		Balance:             &SemanticTagCurrencyAmount{Amount: "10", CurrencyCode: "EUR"},
+		CurrentArrivalDate:  &now,
+		DepartureLocation:   &SemanticTagLocation{Latitude: 1, Longitude: 2},
+		Duration:            &duration,
+		EventStartDateInfo:  &SemanticTagEventDateInfo{Date: &now, OriginalDate: &later},
+		PassengerName:       &SemanticTagPersonNameComponents{GivenName: "John"},
+		Seats:               []SemanticTagSeat{{SeatNumber: "1"}},
+		VenueLocation:       &SemanticTagLocation{Latitude: 1, Longitude: 2},
+		RelevantDates:       []PassRelevantDate{{Date: &now}},
+		WifiAccess:          []SemanticTagWifiNetwork{{SSID: "ssid", Password: "pass"}},
+		DestinationLocation: &SemanticTagLocation{Latitude: 1, Longitude: 2},
+	}
+	p.EventDetail = &EventDetail{EventStartDate: &now, EventEndDate: &later, EventLocation: &Location{Latitude: 1}}
+	p.VenueDetail = &VenueDetail{VenueLocation: &Location{Latitude: 1}}
+	p.TicketDetail = &TicketDetail{TicketSeat: "1"}
+	p.PreferredStyleSchemes = []StyleScheme{StyleSchemePosterEventTicket}
+	p.UpcomingPassInformation = []UpcomingPass{{Identifier: "1", DateInformation: &UpcomingPassDateInformation{Date: &now}}}
+
+	return &p
+}
+
+// assertNoSharedMemory walks both values and fails if any pointer, slice or map of the clone points to the same
+// memory as the original.
+func assertNoSharedMemory(t *testing.T, path string, a, b reflect.Value) {
+	t.Helper()
+
+	switch a.Kind() {
+	case reflect.Pointer:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared between the original and the clone", path)
+			return
+		}
+		assertNoSharedMemory(t, path, a.Elem(), b.Elem())
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		assertNoSharedMemory(t, path, a.Elem(), b.Elem())
+	case reflect.Slice:
+		if a.Len() == 0 || b.Len() == 0 {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared between the original and the clone", path)
+			return
+		}
+		for i := 0; i < a.Len(); i++ {
+			assertNoSharedMemory(t, path+"[]", a.Index(i), b.Index(i))
+		}
+	case reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			return
+		}
+		if a.Pointer() == b.Pointer() {
+			t.Errorf("%s is shared between the original and the clone", path)
+			return
+		}
+		for _, k := range a.MapKeys() {
+			assertNoSharedMemory(t, path+"."+k.String(), a.MapIndex(k), b.MapIndex(k))
+		}
+	case reflect.Struct:
+		if a.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			assertNoSharedMemory(t, path+"."+a.Type().Field(i).Name, a.Field(i), b.Field(i))
+		}
+	}
+}
+
+func TestPass_Clone(t *testing.T) {
+	p := getFullPass()
+	c := p.Clone()
+
+	if !reflect.DeepEqual(p, c) {
+		t.Errorf("Clone should be equal to the original pass")
+	}
+
+	assertNoSharedMemory(t, "Pass", reflect.ValueOf(p), reflect.ValueOf(c))
+}
+
+func TestPass_CloneIsIndependent(t *testing.T) {
+	p := getFullPass()
+	c := p.Clone()
+
+	c.EventTicket.PrimaryFields[0].Value = "changed"
+	c.EventTicket.AddBackFields(Field{Key: "new", Value: "new"})
+	c.UserInfo["nested"].(map[string]interface{})["list"].([]interface{})[0] = "changed"
+	*c.Semantics.CurrentArrivalDate = c.Semantics.CurrentArrivalDate.Add(time.Hour)
+
+	if p.EventTicket.PrimaryFields[0].Value != "value" {
+		t.Errorf("Changing a field of the clone should not change the original")
+	}
+
+	if len(p.EventTicket.BackFields) != 1 {
+		t.Errorf("Adding a field to the clone should not change the original")
+	}
+
+	if p.UserInfo["nested"].(map[string]interface{})["list"].([]interface{})[0] != "a" {
+		t.Errorf("Changing the UserInfo of the clone should not change the original")
+	}
+
+	if p.Semantics.CurrentArrivalDate.Equal(*c.Semantics.CurrentArrivalDate) {
+		t.Errorf("Changing a date of the clone should not change the original")
+	}
+}
+
+func TestPass_CloneNil(t *testing.T) {
+	var p *Pass
+	if p.Clone() != nil {
+		t.Errorf("Clone of a nil pass should be nil")
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alvinbaena/passkit"
+)
+
+func runBundle(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("bundle", flag.ContinueOnError)
+	out := fs.String("out", "passes.pkpasses", "the bundle file to write")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if fs.NArg() == 0 {
+		return errors.New("usage: passkit bundle -out passes.pkpasses <file.pkpass>...")
+	}
+
+	var archives []passkit.PassArchive
+	for _, path := range fs.Args() {
+		z, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		archives = append(archives, z)
+	}
+
+	b, err := passkit.NewMemoryBasedSigner().CreatePassBundleArchive(archives...)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, b, 0644); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(stdout, "wrote %s with %d passes\n", *out, len(archives))
+	return nil
+}
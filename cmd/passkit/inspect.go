@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/alvinbaena/passkit"
+	"go.mozilla.org/pkcs7"
+)
+
+func runInspect(args []string, stdout io.Writer) error {
+	if len(args) != 1 {
+		return errors.New("usage: passkit inspect <file.pkpass>")
+	}
+
+	z, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	files, err := passkit.ReadArchiveFiles(z)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range []string{"pass.json", "order.json", "personalization.json"} {
+		if b, ok := files[name]; ok {
+			var out bytes.Buffer
+			if err := json.Indent(&out, b, "", "  "); err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			_, _ = fmt.Fprintf(stdout, "%s:\n%s\n\n", name, out.String())
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	_, _ = fmt.Fprintln(stdout, "files:")
+	for _, name := range names {
+		_, _ = fmt.Fprintf(stdout, "  %-40s %8d bytes\n", name, len(files[name]))
+	}
+
+	signature, ok := files["signature"]
+	if !ok {
+		_, _ = fmt.Fprintln(stdout, "\nthe archive is not signed")
+		return nil
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(stdout, "\ncertificates:")
+	for _, c := range p7.Certificates {
+		_, _ = fmt.Fprintf(stdout, "  subject: %s\n  issuer:  %s\n  serial:  %s\n  valid:   %s - %s\n\n",
+			c.Subject, c.Issuer, c.SerialNumber, c.NotBefore.Format(time.RFC3339), c.NotAfter.Format(time.RFC3339))
+	}
+
+	var signingTime time.Time
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeSigningTime, &signingTime); err == nil {
+		_, _ = fmt.Fprintf(stdout, "signed at: %s\n", signingTime.Format(time.RFC3339))
+	}
+
+	ts, err := passkit.SignatureTimestamp(signature)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	if ts != nil {
+		_, _ = fmt.Fprintf(stdout, "timestamped at: %s\n", ts.Format(time.RFC3339))
+	}
+
+	return nil
+}
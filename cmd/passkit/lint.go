@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/alvinbaena/passkit"
+)
+
+func runLint(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	passPath := fs.String("pass", "pass.json", "the pass.json file")
+	templateDir := fs.String("template", "", "the directory with the images and translations of the pass, to check the bundle files too")
+	personalizationPath := fs.String("personalization", "", "the personalization.json file, if the pass is personalized")
+	allowHTTP := fs.Bool("allow-http", false, "accept a plain HTTP webServiceURL, for development")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	p, err := loadPass(*passPath)
+	if err != nil {
+		return err
+	}
+	p.SetAllowHTTPWebService(*allowHTTP)
+
+	pz, err := loadPersonalization(*personalizationPath)
+	if err != nil {
+		return err
+	}
+
+	var findings passkit.ValidationFindings
+	if *templateDir != "" {
+		findings, err = passkit.ValidatePassBundle(p, pz, passkit.NewFolderPassTemplate(*templateDir))
+		if err != nil {
+			return err
+		}
+	} else {
+		findings = p.GetValidationFindings()
+		if pz != nil {
+			findings = append(findings, pz.GetValidationFindings()...)
+		}
+	}
+
+	printFindings(stdout, findings)
+	if findings.HasErrors() {
+		return errLint
+	}
+
+	_, _ = fmt.Fprintf(stdout, "%s: %d warnings\n", *passPath, len(findings.Warnings()))
+	return nil
+}
@@ -0,0 +1,118 @@
+// Command passkit signs, verifies, inspects, bundles and lints Apple Wallet passes.
+//
+// Usage:
+//
+//	passkit sign -pass pass.json -template dir -p12 cert.p12 -password pass -wwdr AppleWWDRCA.cer -out pass.pkpass
+//	passkit verify pass.pkpass
+//	passkit inspect pass.pkpass
+//	passkit bundle -out passes.pkpasses pass1.pkpass pass2.pkpass
+//	passkit lint -pass pass.json [-template dir]
+//	passkit serve -pass pass.json -template dir -p12 cert.p12 -password pass -wwdr AppleWWDRCA.cer -url http://192.168.1.10:8080
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/alvinbaena/passkit"
+)
+
+type command struct {
+	usage string
+	run   func(args []string, stdout io.Writer) error
+}
+
+var commands = map[string]command{
+	"sign":    {"sign a pass.json with a template directory into a .pkpass", runSign},
+	"verify":  {"check the manifest hashes and signature of a .pkpass or .order", runVerify},
+	"inspect": {"print the pass.json, certificates and files of a .pkpass or .order", runInspect},
+	"bundle":  {"bundle several .pkpass files into a .pkpasses file", runBundle},
+	"lint":    {"report the validation errors and warnings of a pass.json", runLint},
+	"serve":   {"serve a pass to devices, signing it again when its files change", runServe},
+}
+
+// errLint is returned by lint when the pass has validation errors, which were already printed.
+var errLint = errors.New("the pass has validation errors")
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		_, _ = fmt.Fprintf(stderr, "passkit: unknown command %q\n", args[0])
+		usage(stderr)
+		return 2
+	}
+
+	if err := cmd.run(args[1:], stdout); err != nil {
+		if !errors.Is(err, errLint) {
+			_, _ = fmt.Fprintf(stderr, "passkit %s: %v\n", args[0], err)
+		}
+		return 1
+	}
+
+	return 0
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "usage: passkit <command> [arguments]\n\ncommands:")
+
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, _ = fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].usage)
+	}
+}
+
+func loadPass(path string) (*passkit.Pass, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p passkit.Pass
+	if err := json.Unmarshal(b, &p); err != nil {
+		return nil, fmt.Errorf("invalid pass %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+func loadPersonalization(path string) (*passkit.Personalization, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pz passkit.Personalization
+	if err := json.Unmarshal(b, &pz); err != nil {
+		return nil, fmt.Errorf("invalid personalization %s: %w", path, err)
+	}
+
+	return &pz, nil
+}
+
+func printFindings(w io.Writer, findings passkit.ValidationFindings) {
+	for _, f := range findings {
+		_, _ = fmt.Fprintln(w, f)
+	}
+}
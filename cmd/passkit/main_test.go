@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"frobnicate"}, &stdout, &stderr); code != 2 {
+		t.Errorf("Unknown command should exit with 2, got %d", code)
+	}
+
+	if !strings.Contains(stderr.String(), "usage") {
+		t.Errorf("Unknown command should print the usage, got %q", stderr.String())
+	}
+}
+
+func TestRun_Lint(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.json")
+	invalid := filepath.Join(dir, "invalid.json")
+
+	_ = os.WriteFile(valid, []byte(`{"formatVersion":1,"serialNumber":"1","passTypeIdentifier":"pass.test","teamIdentifier":"TEAM",
+		"organizationName":"Org","description":"desc","generic":{},
+		"barcodes":[{"format":"PKBarcodeFormatCode128","message":"1","messageEncoding":"utf-8"}]}`), 0644)
+	_ = os.WriteFile(invalid, []byte(`{"formatVersion":1}`), 0644)
+
+	var stdout, stderr bytes.Buffer
+	if code := run([]string{"lint", "-pass", valid}, &stdout, &stderr); code != 0 {
+		t.Errorf("Linting a valid pass should exit with 0, got %d. %s", code, stderr.String())
+	}
+
+	if !strings.Contains(stdout.String(), "warning: ") {
+		t.Errorf("Lint should print the warnings, got %q", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := run([]string{"lint", "-pass", invalid}, &stdout, &stderr); code != 1 {
+		t.Errorf("Linting an invalid pass should exit with 1, got %d", code)
+	}
+
+	if !strings.Contains(stdout.String(), "error: ") {
+		t.Errorf("Lint should print the errors, got %q", stdout.String())
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/alvinbaena/passkit"
+)
+
+func runServe(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	passPath := fs.String("pass", "pass.json", "the pass.json file")
+	templateDir := fs.String("template", "", "the directory with the images and translations of the pass")
+	p12 := fs.String("p12", "", "the PKCS#12 file with the pass type certificate and key")
+	password := fs.String("password", os.Getenv("PASSKIT_P12_PASSWORD"), "the password of the PKCS#12 file, defaults to $PASSKIT_P12_PASSWORD")
+	wwdr := fs.String("wwdr", "", "the Apple WWDR CA certificate file, DER encoded")
+	addr := fs.String("addr", ":8080", "the address to listen on")
+	url := fs.String("url", "", "the URL devices use to reach the server, used as the webServiceURL of the pass")
+	poll := fs.Duration("poll", time.Second, "how often the pass files are checked for changes")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *templateDir == "" || *p12 == "" || *wwdr == "" || *url == "" {
+		return errors.New("-template, -p12, -wwdr and -url are required")
+	}
+
+	info, err := passkit.LoadSigningInformationFromFiles(*p12, *password, *wwdr)
+	if err != nil {
+		return err
+	}
+
+	d := passkit.NewDevServer(*url, info, passkit.WithWarningHandler(func(_ *passkit.Pass, warnings passkit.ValidationFindings) {
+		printFindings(stdout, warnings)
+	}))
+	d.PollInterval = *poll
+
+	if err := d.AddPass(*passPath, *templateDir); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	go d.Run(ctx)
+
+	srv := &http.Server{Addr: *addr, Handler: d}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Shutdown(context.Background())
+	}()
+
+	_, _ = fmt.Fprintf(stdout, "serving %s at %s\n", *passPath, *url)
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alvinbaena/passkit"
+)
+
+func runSign(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	passPath := fs.String("pass", "pass.json", "the pass.json file")
+	templateDir := fs.String("template", "", "the directory with the images and translations of the pass")
+	personalizationPath := fs.String("personalization", "", "the personalization.json file, if the pass is personalized")
+	p12 := fs.String("p12", "", "the PKCS#12 file with the pass type certificate and key")
+	password := fs.String("password", os.Getenv("PASSKIT_P12_PASSWORD"), "the password of the PKCS#12 file, defaults to $PASSKIT_P12_PASSWORD")
+	wwdr := fs.String("wwdr", "", "the Apple WWDR CA certificate file, DER encoded")
+	out := fs.String("out", "pass.pkpass", "the signed pass file to write")
+	allowHTTP := fs.Bool("allow-http", false, "accept a plain HTTP webServiceURL, for development")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *templateDir == "" || *p12 == "" || *wwdr == "" {
+		return errors.New("-template, -p12 and -wwdr are required")
+	}
+
+	p, err := loadPass(*passPath)
+	if err != nil {
+		return err
+	}
+	p.SetAllowHTTPWebService(*allowHTTP)
+
+	pz, err := loadPersonalization(*personalizationPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := passkit.LoadSigningInformationFromFiles(*p12, *password, *wwdr)
+	if err != nil {
+		return err
+	}
+
+	signer := passkit.NewMemoryBasedSigner(passkit.WithWarningHandler(func(_ *passkit.Pass, warnings passkit.ValidationFindings) {
+		printFindings(stdout, warnings)
+	}))
+
+	z, err := signer.CreateSignedAndZippedPersonalizedPassArchive(p, pz, passkit.NewFolderPassTemplate(*templateDir), info)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*out, z, 0644); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(stdout, "wrote %s\n", *out)
+	return nil
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/alvinbaena/passkit"
+)
+
+func runVerify(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: passkit verify <file.pkpass>...")
+	}
+
+	for _, path := range args {
+		z, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := passkit.VerifyPassArchive(z); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		_, _ = fmt.Fprintf(stdout, "%s: OK\n", path)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package passkit
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+type DeviceLogCategory string
+
+const (
+	DeviceLogCategorySignature      DeviceLogCategory = "signature"
+	DeviceLogCategoryManifest       DeviceLogCategory = "manifest"
+	DeviceLogCategoryCertificate    DeviceLogCategory = "certificate"
+	DeviceLogCategoryAuthentication DeviceLogCategory = "authentication"
+	DeviceLogCategoryInvalidPass    DeviceLogCategory = "invalid_pass"
+	DeviceLogCategoryWebService     DeviceLogCategory = "web_service"
+	DeviceLogCategoryUnknown        DeviceLogCategory = "unknown"
+)
+
+// DeviceLogEntry is a message sent by a device to the log endpoint of the web service, with the kind of problem it
+// reports.
+type DeviceLogEntry struct {
+	Message  string
+	Category DeviceLogCategory
+	// PassTypeIdentifier and SerialNumber identify the pass the message is about, when the message names it.
+	PassTypeIdentifier string
+	SerialNumber       string
+}
+
+// deviceLogPatterns are the known error messages logged by Wallet, checked in order.
+var deviceLogPatterns = []struct {
+	pattern  *regexp.Regexp
+	category DeviceLogCategory
+}{
+	{regexp.MustCompile(`(?i)signature does not validate|signature (is )?(invalid|not valid)|verify (the )?signature`), DeviceLogCategorySignature},
+	{regexp.MustCompile(`(?i)manifest`), DeviceLogCategoryManifest},
+	{regexp.MustCompile(`(?i)certificate`), DeviceLogCategoryCertificate},
+	{regexp.MustCompile(`(?i)authenticat|unauthorized|(status|response) code 401`), DeviceLogCategoryAuthentication},
+	{regexp.MustCompile(`(?i)invalid data error reading pass`), DeviceLogCategoryInvalidPass},
+	{regexp.MustCompile(`(?i)web service error|unexpected response code|could not connect|timed out|lastUpdated tag`), DeviceLogCategoryWebService},
+}
+
+// deviceLogPassPatterns extract the pass type identifier and serial number from the messages naming the pass.
+var deviceLogPassPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`pass type ([^,\s)]+), serial(?: number)? ([^,\s)]+)`),
+	regexp.MustCompile(`reading pass ([^/\s]+)/(\S+)`),
+	regexp.MustCompile(`Web service error for ([^\s(]+)`),
+}
+
+// ParseDeviceLog categorizes a message sent by a device to the log endpoint.
+func ParseDeviceLog(message string) DeviceLogEntry {
+	e := DeviceLogEntry{Message: message, Category: DeviceLogCategoryUnknown}
+
+	for _, p := range deviceLogPatterns {
+		if p.pattern.MatchString(message) {
+			e.Category = p.category
+			break
+		}
+	}
+
+	for _, p := range deviceLogPassPatterns {
+		if m := p.FindStringSubmatch(message); m != nil {
+			e.PassTypeIdentifier = m[1]
+			if len(m) > 2 {
+				e.SerialNumber = strings.TrimSuffix(m[2], ".")
+			}
+			break
+		}
+	}
+
+	return e
+}
+
+// SlogDeviceLogHandler returns a device log handler, for WithDeviceLogHandler, that writes the entries to h. Known
+// errors are logged at the error level, web service problems as warnings, and other messages as info.
+func SlogDeviceLogHandler(h slog.Handler) func(ctx context.Context, entries []DeviceLogEntry) {
+	logger := slog.New(h)
+
+	return func(ctx context.Context, entries []DeviceLogEntry) {
+		for _, e := range entries {
+			level := slog.LevelError
+			switch e.Category {
+			case DeviceLogCategoryWebService:
+				level = slog.LevelWarn
+			case DeviceLogCategoryUnknown:
+				level = slog.LevelInfo
+			}
+
+			attrs := []slog.Attr{slog.String("category", string(e.Category)), slog.String("message", e.Message)}
+			if e.PassTypeIdentifier != "" {
+				attrs = append(attrs, slog.String("passTypeIdentifier", e.PassTypeIdentifier))
+			}
+			if e.SerialNumber != "" {
+				attrs = append(attrs, slog.String("serialNumber", e.SerialNumber))
+			}
+
+			logger.LogAttrs(ctx, level, "wallet device log", attrs...)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package passkit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+)
+
+func TestParseDeviceLog(t *testing.T) {
+	tests := []struct {
+		message  string
+		category DeviceLogCategory
+		ptid     string
+		serial   string
+	}{
+		{"Passbook: Signature does not validate for pass pass.test", DeviceLogCategorySignature, "", ""},
+		{"Invalid data error reading pass pass.test/1234. Manifest file hash does not match", DeviceLogCategoryManifest, "pass.test", "1234"},
+		{"Invalid data error reading pass pass.test/1234. Pass dictionary must contain key 'description'.", DeviceLogCategoryInvalidPass, "pass.test", "1234"},
+		{"The passTypeIdentifier or teamIdentifier provided may not match your certificate", DeviceLogCategoryCertificate, "", ""},
+		{"Register task (pass type pass.test, serial number 1234, with web service url https://example.com) encountered error: Authentication failure", DeviceLogCategoryAuthentication, "pass.test", "1234"},
+		{"Web service error for pass.test (https://example.com): Unexpected response code 500", DeviceLogCategoryWebService, "pass.test", ""},
+		{"something else", DeviceLogCategoryUnknown, "", ""},
+	}
+
+	for _, test := range tests {
+		e := ParseDeviceLog(test.message)
+		if e.Category != test.category || e.PassTypeIdentifier != test.ptid || e.SerialNumber != test.serial || e.Message != test.message {
+			t.Errorf("%q: expected %s %q %q, got %s %q %q", test.message, test.category, test.ptid, test.serial, e.Category, e.PassTypeIdentifier, e.SerialNumber)
+		}
+	}
+}
+
+func TestWebService_DeviceLogHandler(t *testing.T) {
+	var entries []DeviceLogEntry
+	s := newTestWebService(WithDeviceLogHandler(func(_ context.Context, e []DeviceLogEntry) { entries = e }))
+
+	if w := doWebServiceRequest(s, http.MethodPost, "/v1/log", "", `{"logs":["Signature does not validate","other"]}`); w.Code != http.StatusOK {
+		t.Errorf("Logging should return 200, got %d", w.Code)
+	}
+
+	if len(entries) != 2 || entries[0].Category != DeviceLogCategorySignature || entries[1].Category != DeviceLogCategoryUnknown {
+		t.Errorf("Logs should be categorized, got %v", entries)
+	}
+}
+
+func TestSlogDeviceLogHandler(t *testing.T) {
+	var buf bytes.Buffer
+	s := newTestWebService(WithDeviceLogHandler(SlogDeviceLogHandler(slog.NewJSONHandler(&buf, nil))))
+
+	doWebServiceRequest(s, http.MethodPost, "/v1/log", "", `{"logs":["Invalid data error reading pass pass.test/1. Manifest file hash does not match"]}`)
+
+	var record map[string]string
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("could not read log record. %v", err)
+	}
+
+	if record["level"] != "ERROR" || record["category"] != "manifest" || record["passTypeIdentifier"] != "pass.test" || record["serialNumber"] != "1" {
+		t.Errorf("unexpected log record %v", record)
+	}
+}
@@ -0,0 +1,337 @@
+package passkit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultDevServerPollInterval = time.Second
+
+// DevServer serves passes signed from pass.json files and template folders on disk, so they can be tested on
+// devices without deploying them. The passes are signed again when their files change, and the web service
+// endpoints are served with in-memory registrations, so devices that added a pass are notified and get the changes
+// too.
+//
+// The webServiceURL of the passes is replaced with the URL of the server. As it's usually a plain HTTP URL, the
+// devices need the "Allow HTTP Services" developer setting enabled.
+type DevServer struct {
+	// PollInterval is how often the files of the passes are checked for changes, one second by default.
+	PollInterval time.Duration
+	// Notifier notifies the registered devices when a pass is signed again. By default it sends APNs
+	// notifications with the certificate of the passes.
+	Notifier PushNotifier
+
+	url           string
+	info          *SigningInformation
+	signer        Signer
+	registrations RegistrationStore
+	webService    *WebService
+	mux           *http.ServeMux
+
+	mu     sync.RWMutex
+	passes []*devPass
+}
+
+// devPass is a pass served by a DevServer, with the files it is signed from.
+type devPass struct {
+	passPath    string
+	templateDir string
+	fingerprint string
+	token       string
+	key         passKey
+	served      *ServedPass
+	err         error
+}
+
+// NewDevServer creates a DevServer that devices reach at url, signing the passes with info.
+func NewDevServer(url string, info *SigningInformation, opts ...SignerOption) *DevServer {
+	d := &DevServer{
+		PollInterval:  defaultDevServerPollInterval,
+		Notifier:      NewAPNsNotifier(info),
+		url:           strings.TrimSuffix(url, "/"),
+		info:          info,
+		signer:        NewMemoryBasedSigner(opts...),
+		registrations: NewMemoryRegistrationStore(),
+		mux:           http.NewServeMux(),
+	}
+	d.webService = NewWebService(d, WithRegistrationStore(d.registrations))
+
+	d.mux.Handle("/v1/", d.webService)
+	d.mux.HandleFunc("GET /passes/{name}", d.servePass)
+	d.mux.HandleFunc("GET /passes.pkpasses", d.serveBundle)
+	d.mux.HandleFunc("GET /{$}", d.serveIndex)
+
+	return d
+}
+
+// AddPass signs the pass.json at passPath with the template at templateDir, and serves it at
+// /passes/<serialNumber>.pkpass.
+func (d *DevServer) AddPass(passPath, templateDir string) error {
+	p := &devPass{passPath: passPath, templateDir: templateDir}
+	if err := d.load(p); err != nil {
+		return err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.passes = append(d.passes, p)
+	return nil
+}
+
+// Reload signs again the passes whose files changed since they were last signed, and notifies the devices
+// registered for them. If a pass can't be signed the previous version keeps being served, and the error is logged.
+func (d *DevServer) Reload() {
+	d.mu.RLock()
+	passes := append([]*devPass(nil), d.passes...)
+	d.mu.RUnlock()
+
+	for _, p := range passes {
+		d.mu.RLock()
+		previous := p.fingerprint
+		d.mu.RUnlock()
+
+		fingerprint, err := sourceFingerprint(p.passPath, p.templateDir)
+		if err != nil || fingerprint == previous {
+			continue
+		}
+
+		if err := d.load(p); err != nil {
+			slog.Error("could not sign pass", "pass", p.passPath, "error", err)
+			continue
+		}
+
+		d.mu.RLock()
+		key := p.key
+		d.mu.RUnlock()
+
+		slog.Info("pass signed again", "pass", p.passPath, "serialNumber", key.serialNumber)
+		d.notify(key)
+	}
+}
+
+// notify sends a push notification to every device registered for the pass. Devices whose push token is no longer
+// registered in APNs are unregistered.
+func (d *DevServer) notify(key passKey) {
+	ctx := context.Background()
+	registrations, err := d.registrations.Registrations(ctx, key.passTypeIdentifier, key.serialNumber)
+	if err != nil {
+		slog.Error("could not read registrations", "serialNumber", key.serialNumber, "error", err)
+		return
+	}
+
+	for _, r := range registrations {
+		err := d.Notifier.Notify(ctx, key.passTypeIdentifier, r.PushToken)
+		if isUnregisteredPushToken(err) {
+			err = d.registrations.Unregister(ctx, r.DeviceLibraryIdentifier, key.passTypeIdentifier, key.serialNumber)
+		}
+
+		if err != nil {
+			slog.Error("could not notify device", "serialNumber", key.serialNumber, "device", r.DeviceLibraryIdentifier, "error", err)
+		}
+	}
+}
+
+// Run checks the files of the passes for changes every PollInterval, until ctx is done.
+func (d *DevServer) Run(ctx context.Context) {
+	interval := d.PollInterval
+	if interval <= 0 {
+		interval = defaultDevServerPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.Reload()
+		}
+	}
+}
+
+// load signs the pass from its files, and marks it as updated in the web service.
+func (d *DevServer) load(p *devPass) error {
+	fingerprint, err := sourceFingerprint(p.passPath, p.templateDir)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(p.passPath)
+	if err != nil {
+		return err
+	}
+
+	var pass Pass
+	if err := json.Unmarshal(b, &pass); err != nil {
+		return fmt.Errorf("invalid pass %s: %w", p.passPath, err)
+	}
+
+	d.mu.RLock()
+	token := p.token
+	d.mu.RUnlock()
+
+	// Keep the same generated token across reloads, so the devices stay authenticated
+	if pass.AuthenticationToken != "" {
+		token = pass.AuthenticationToken
+	} else if token == "" {
+		if token, err = GenerateAuthenticationToken(); err != nil {
+			return err
+		}
+	}
+
+	pass.AuthenticationToken = token
+	pass.WebServiceURL = d.url
+	pass.SetAllowHTTPWebService(true)
+
+	z, err := d.signer.CreateSignedAndZippedPassArchive(&pass, NewFolderPassTemplate(p.templateDir), d.info)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p.fingerprint = fingerprint
+	p.err = err
+	if err != nil {
+		return err
+	}
+
+	p.token = token
+	p.key = passKey{pass.PassTypeIdentifier, pass.SerialNumber}
+	p.served = &ServedPass{Archive: z, AuthenticationToken: token, LastModified: time.Now()}
+	return d.webService.PassUpdated(context.Background(), pass.PassTypeIdentifier, pass.SerialNumber)
+}
+
+// Pass implements PassProvider for the web service of the server.
+func (d *DevServer) Pass(_ context.Context, passTypeIdentifier, serialNumber string) (*ServedPass, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	for _, p := range d.passes {
+		if p.served != nil && p.key == (passKey{passTypeIdentifier, serialNumber}) {
+			return p.served, nil
+		}
+	}
+
+	return nil, ErrPassNotFound
+}
+
+func (d *DevServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mux.ServeHTTP(w, r)
+}
+
+func (d *DevServer) servePass(w http.ResponseWriter, r *http.Request) {
+	serialNumber, ok := strings.CutSuffix(r.PathValue("name"), ".pkpass")
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	d.mu.RLock()
+	var served *ServedPass
+	for _, p := range d.passes {
+		if p.served != nil && p.key.serialNumber == serialNumber {
+			served = p.served
+		}
+	}
+	d.mu.RUnlock()
+
+	if served == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	ServePass(w, r, served.Archive, served.LastModified)
+}
+
+func (d *DevServer) serveBundle(w http.ResponseWriter, r *http.Request) {
+	d.mu.RLock()
+	var archives []PassArchive
+	var lastModified time.Time
+	for _, p := range d.passes {
+		if p.served != nil {
+			archives = append(archives, p.served.Archive)
+			if p.served.LastModified.After(lastModified) {
+				lastModified = p.served.LastModified
+			}
+		}
+	}
+	d.mu.RUnlock()
+
+	b, err := d.signer.CreatePassBundleArchive(archives...)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ServePassBundle(w, r, b, lastModified)
+}
+
+var devServerIndex = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html><head><meta name="viewport" content="width=device-width"><title>passkit</title></head><body>
+<ul>
+{{range .}}<li>{{if .Serial}}<a href="/passes/{{.Serial}}.pkpass">{{.Serial}}</a>{{else}}{{.Path}}{{end}}{{if .Err}} ({{.Err}}){{end}}</li>
+{{end}}</ul>
+<a href="/passes.pkpasses">All passes</a>
+</body></html>
+`))
+
+func (d *DevServer) serveIndex(w http.ResponseWriter, _ *http.Request) {
+	type entry struct {
+		Path, Serial string
+		Err          error
+	}
+
+	d.mu.RLock()
+	var entries []entry
+	for _, p := range d.passes {
+		entries = append(entries, entry{Path: p.passPath, Serial: p.key.serialNumber, Err: p.err})
+	}
+	d.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	_ = devServerIndex.Execute(w, entries)
+}
+
+// sourceFingerprint summarizes the names, sizes and modification times of the files of a pass, so any change to
+// them changes the fingerprint.
+func sourceFingerprint(passPath, templateDir string) (string, error) {
+	var entries []string
+
+	fi, err := os.Stat(passPath)
+	if err != nil {
+		return "", err
+	}
+	entries = append(entries, fmt.Sprintf("%s:%d:%d", passPath, fi.Size(), fi.ModTime().UnixNano()))
+
+	err = filepath.WalkDir(templateDir, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		fi, err := e.Info()
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, fmt.Sprintf("%s:%d:%d", path, fi.Size(), fi.ModTime().UnixNano()))
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(entries)
+	return strings.Join(entries, "\n"), nil
+}
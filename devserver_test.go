@@ -0,0 +1,111 @@
+package passkit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestPassFiles(t *testing.T, dir, description string, mtime time.Time) string {
+	t.Helper()
+
+	pass := getBasicPass()
+	pass.Description = description
+	b, err := json.Marshal(pass)
+	if err != nil {
+		t.Fatalf("could not marshal pass. %v", err)
+	}
+
+	passPath := filepath.Join(dir, "pass.json")
+	if err := os.WriteFile(passPath, b, 0644); err != nil {
+		t.Fatalf("could not write pass. %v", err)
+	}
+
+	if err := os.Chtimes(passPath, mtime, mtime); err != nil {
+		t.Fatalf("could not change pass modification time. %v", err)
+	}
+
+	return passPath
+}
+
+func TestDevServer(t *testing.T) {
+	dir := t.TempDir()
+	templateDir := filepath.Join(dir, "template")
+	if err := os.Mkdir(templateDir, 0755); err != nil {
+		t.Fatalf("could not create template. %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(templateDir, BundleIcon), []byte("icon"), 0644); err != nil {
+		t.Fatalf("could not write icon. %v", err)
+	}
+
+	passPath := writeTestPassFiles(t, dir, "first", time.Now().Add(-time.Hour))
+
+	d := NewDevServer("http://localhost:8080/", newTestSigningInformation(t))
+	notifier := &testNotifier{}
+	d.Notifier = notifier
+	if err := d.AddPass(passPath, templateDir); err != nil {
+		t.Fatalf("could not add pass. %v", err)
+	}
+
+	serial := getBasicPass().SerialNumber
+	w := httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/passes/"+serial+".pkpass", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != PassMimeType {
+		t.Fatalf("Pass should be served, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	files := readZip(t, w.Body.Bytes())
+	var served Pass
+	if err := json.Unmarshal(files[passJsonFileName], &served); err != nil {
+		t.Fatalf("could not read served pass. %v", err)
+	}
+
+	if served.WebServiceURL != "http://localhost:8080" || served.AuthenticationToken == "" {
+		t.Errorf("Served pass should use the dev server web service, got %q %q", served.WebServiceURL, served.AuthenticationToken)
+	}
+
+	doWebServiceRequest(d, http.MethodPost, "/v1/devices/device1/registrations/"+served.PassTypeIdentifier+"/"+serial, served.AuthenticationToken, `{"pushToken":"push"}`)
+	var resp struct {
+		LastUpdated string `json:"lastUpdated"`
+	}
+	w = doWebServiceRequest(d, http.MethodGet, "/v1/devices/device1/registrations/"+served.PassTypeIdentifier, "", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("could not read updated serials. %v", err)
+	}
+
+	if sent := notifier.sent(); len(sent) != 0 {
+		t.Errorf("Devices should not be notified before the pass changes, got %v", sent)
+	}
+
+	writeTestPassFiles(t, dir, "second", time.Now())
+	d.Reload()
+
+	if sent := notifier.sent(); len(sent) != 1 || sent[0] != served.PassTypeIdentifier+"/push" {
+		t.Errorf("Registered devices should be notified when the pass is signed again, got %v", sent)
+	}
+
+	w = doWebServiceRequest(d, http.MethodGet, "/v1/passes/"+served.PassTypeIdentifier+"/"+serial, served.AuthenticationToken, "")
+	var updated Pass
+	if err := json.Unmarshal(readZip(t, w.Body.Bytes())[passJsonFileName], &updated); err != nil || updated.Description != "second" {
+		t.Errorf("Changed pass should be signed again, got %q. %v", updated.Description, err)
+	}
+
+	if updated.AuthenticationToken != served.AuthenticationToken {
+		t.Errorf("Authentication token should not change when signing again")
+	}
+
+	w = doWebServiceRequest(d, http.MethodGet, "/v1/devices/device1/registrations/"+served.PassTypeIdentifier+"?passesUpdatedSince="+resp.LastUpdated, "", "")
+	if w.Code != http.StatusOK {
+		t.Errorf("Changed pass should be reported as updated, got %d", w.Code)
+	}
+
+	w = httptest.NewRecorder()
+	d.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/passes.pkpasses", nil))
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != PassBundleMimeType {
+		t.Errorf("Pass bundle should be served, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+}
@@ -0,0 +1,413 @@
+package passkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+)
+
+type PassChangeKind string
+type PassChangeType string
+
+const (
+	PassChangeKindProperty PassChangeKind = "property"
+	PassChangeKindField    PassChangeKind = "field"
+	PassChangeKindSemantic PassChangeKind = "semantic"
+
+	PassChangeAdded    PassChangeType = "added"
+	PassChangeRemoved  PassChangeType = "removed"
+	PassChangeModified PassChangeType = "modified"
+)
+
+// passStyleKeys are the pass.json keys holding the pass style. Their fields are diffed one by one instead of as a
+// whole property.
+var passStyleKeys = []string{"eventTicket", "coupon", "storeCard", "boardingPass", "generic"}
+
+// PassChange describes a single difference between two versions of a pass.
+type PassChange struct {
+	Kind PassChangeKind
+	Type PassChangeType
+	// Key is the field key for field changes, the pass.json property name for property changes, or the semantic
+	// tag name for semantic changes.
+	Key string
+	// Section is the name of the field list containing the field, like primaryFields or backFields. Only set for
+	// field changes.
+	Section string
+	// OldValue and NewValue hold the previous and current values. For fields these are the field values, for
+	// properties and semantic tags these are the decoded JSON values.
+	OldValue interface{}
+	NewValue interface{}
+	// ChangeMessage is the change message of the updated field. Only set for field changes.
+	ChangeMessage string
+	// Visible reports whether the change makes Wallet show a notification on the lock screen. This is the case
+	// when the value of a field with a changeMessage is modified.
+	Visible bool
+}
+
+// PassDiff is the list of changes between two versions of a pass, as returned by DiffPasses.
+type PassDiff struct {
+	Changes []PassChange
+}
+
+// HasChanges reports whether the passes are different, meaning the devices holding the pass need to be notified
+// so they fetch the new version.
+func (d *PassDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// HasVisibleChanges reports whether updating the pass will show a notification on the lock screen.
+func (d *PassDiff) HasVisibleChanges() bool {
+	for _, c := range d.Changes {
+		if c.Visible {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ChangedFieldKeys returns the keys of the fields that were added or modified.
+func (d *PassDiff) ChangedFieldKeys() []string {
+	var keys []string
+	for _, c := range d.Changes {
+		if c.Kind == PassChangeKindField && c.Type != PassChangeRemoved {
+			keys = append(keys, c.Key)
+		}
+	}
+
+	return keys
+}
+
+// ApplyChangeMessage sets message as the ChangeMessage of every modified field of p that does not have one
+// already, so the update is announced on the lock screen. p should be the new pass given to DiffPasses. The
+// message must contain the %@ placeholder.
+func (d *PassDiff) ApplyChangeMessage(p *Pass, message string) {
+	fields := passFieldsByKey(p)
+
+	for i, c := range d.Changes {
+		if c.Kind != PassChangeKindField || c.Type != PassChangeModified {
+			continue
+		}
+
+		f, ok := fields[c.Key]
+		if !ok {
+			continue
+		}
+
+		if strings.TrimSpace(f.field.ChangeMessage) == "" {
+			f.field.ChangeMessage = message
+		}
+
+		d.Changes[i].ChangeMessage = f.field.ChangeMessage
+		d.Changes[i].Visible = strings.TrimSpace(f.field.ChangeMessage) != "" && !sameJSON(c.OldValue, c.NewValue)
+	}
+}
+
+// DiffPasses compares two versions of a pass and returns the changes between them. Fields are matched by key,
+// semantic tags by name and everything else by pass.json property name. Either pass can be nil, in which case
+// everything in the other pass is reported as added or removed.
+func DiffPasses(oldPass, newPass *Pass) (*PassDiff, error) {
+	d := &PassDiff{}
+
+	if err := d.diffProperties(oldPass, newPass); err != nil {
+		return nil, err
+	}
+
+	d.diffFields(oldPass, newPass)
+
+	if err := d.diffSemantics(oldPass, newPass); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+func (d *PassDiff) diffProperties(oldPass, newPass *Pass) error {
+	oldProps, err := jsonProperties(oldPass)
+	if err != nil {
+		return err
+	}
+
+	newProps, err := jsonProperties(newPass)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range passStyleKeys {
+		oldStyle, inOld := oldProps[k]
+		newStyle, inNew := newProps[k]
+		delete(oldProps, k)
+		delete(newProps, k)
+
+		switch {
+		case inOld && !inNew:
+			d.Changes = append(d.Changes, PassChange{Kind: PassChangeKindProperty, Type: PassChangeRemoved, Key: k})
+		case !inOld && inNew:
+			d.Changes = append(d.Changes, PassChange{Kind: PassChangeKindProperty, Type: PassChangeAdded, Key: k})
+		case inOld && inNew:
+			// Only the transit type lives outside the field lists
+			if k == "boardingPass" {
+				if err := d.diffTransitType(oldStyle, newStyle); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	delete(oldProps, "semantics")
+	delete(newProps, "semantics")
+
+	return d.diffJSONProperties(PassChangeKindProperty, oldProps, newProps)
+}
+
+func (d *PassDiff) diffTransitType(oldStyle, newStyle json.RawMessage) error {
+	var o, n struct {
+		TransitType TransitType `json:"transitType"`
+	}
+
+	if err := json.Unmarshal(oldStyle, &o); err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(newStyle, &n); err != nil {
+		return err
+	}
+
+	if o.TransitType != n.TransitType {
+		d.Changes = append(d.Changes, PassChange{
+			Kind:     PassChangeKindProperty,
+			Type:     PassChangeModified,
+			Key:      "transitType",
+			OldValue: o.TransitType,
+			NewValue: n.TransitType,
+		})
+	}
+
+	return nil
+}
+
+func (d *PassDiff) diffSemantics(oldPass, newPass *Pass) error {
+	var oldSemantics, newSemantics *SemanticTag
+	if oldPass != nil {
+		oldSemantics = oldPass.Semantics
+	}
+	if newPass != nil {
+		newSemantics = newPass.Semantics
+	}
+
+	oldProps, err := jsonProperties(oldSemantics)
+	if err != nil {
+		return err
+	}
+
+	newProps, err := jsonProperties(newSemantics)
+	if err != nil {
+		return err
+	}
+
+	return d.diffJSONProperties(PassChangeKindSemantic, oldProps, newProps)
+}
+
+func (d *PassDiff) diffJSONProperties(kind PassChangeKind, oldProps, newProps map[string]json.RawMessage) error {
+	keys := make(map[string]struct{}, len(oldProps)+len(newProps))
+	for k := range oldProps {
+		keys[k] = struct{}{}
+	}
+	for k := range newProps {
+		keys[k] = struct{}{}
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		o, inOld := oldProps[k]
+		n, inNew := newProps[k]
+		if inOld && inNew && bytes.Equal(o, n) {
+			continue
+		}
+
+		c := PassChange{Kind: kind, Key: k}
+		switch {
+		case !inOld:
+			c.Type = PassChangeAdded
+		case !inNew:
+			c.Type = PassChangeRemoved
+		default:
+			c.Type = PassChangeModified
+		}
+
+		if inOld {
+			if err := json.Unmarshal(o, &c.OldValue); err != nil {
+				return err
+			}
+		}
+		if inNew {
+			if err := json.Unmarshal(n, &c.NewValue); err != nil {
+				return err
+			}
+		}
+
+		d.Changes = append(d.Changes, c)
+	}
+
+	return nil
+}
+
+func (d *PassDiff) diffFields(oldPass, newPass *Pass) {
+	oldFields := passFieldsByKey(oldPass)
+	newFields := passFieldsByKey(newPass)
+
+	for _, k := range sortedFieldKeys(oldFields) {
+		if _, ok := newFields[k]; !ok {
+			o := oldFields[k]
+			d.Changes = append(d.Changes, PassChange{
+				Kind:     PassChangeKindField,
+				Type:     PassChangeRemoved,
+				Key:      k,
+				Section:  o.section,
+				OldValue: o.field.Value,
+			})
+		}
+	}
+
+	for _, k := range sortedFieldKeys(newFields) {
+		n := newFields[k]
+		o, ok := oldFields[k]
+		if !ok {
+			d.Changes = append(d.Changes, PassChange{
+				Kind:          PassChangeKindField,
+				Type:          PassChangeAdded,
+				Key:           k,
+				Section:       n.section,
+				NewValue:      n.field.Value,
+				ChangeMessage: n.field.ChangeMessage,
+			})
+			continue
+		}
+
+		if o.section == n.section && sameJSON(o.field, n.field) {
+			continue
+		}
+
+		valueChanged := !sameJSON(o.field.Value, n.field.Value)
+		d.Changes = append(d.Changes, PassChange{
+			Kind:          PassChangeKindField,
+			Type:          PassChangeModified,
+			Key:           k,
+			Section:       n.section,
+			OldValue:      o.field.Value,
+			NewValue:      n.field.Value,
+			ChangeMessage: n.field.ChangeMessage,
+			Visible:       valueChanged && strings.TrimSpace(n.field.ChangeMessage) != "",
+		})
+	}
+}
+
+type sectionField struct {
+	section string
+	field   *Field
+}
+
+// passFieldsByKey indexes all the fields of the pass style by key. The returned fields point into p.
+func passFieldsByKey(p *Pass) map[string]sectionField {
+	ret := make(map[string]sectionField)
+
+	gp := p.genericPass()
+	if gp == nil {
+		return ret
+	}
+
+	sections := []struct {
+		name   string
+		fields []Field
+	}{
+		{"headerFields", gp.HeaderFields},
+		{"primaryFields", gp.PrimaryFields},
+		{"secondaryFields", gp.SecondaryFields},
+		{"auxiliaryFields", gp.AuxiliaryFields},
+		{"backFields", gp.BackFields},
+		{"additionalInfoFields", gp.AdditionalInfoFields},
+	}
+
+	for _, s := range sections {
+		for i := range s.fields {
+			ret[s.fields[i].Key] = sectionField{section: s.name, field: &s.fields[i]}
+		}
+	}
+
+	return ret
+}
+
+// genericPass returns the field lists of whichever pass style is set, or nil if none is.
+func (p *Pass) genericPass() *GenericPass {
+	switch {
+	case p == nil:
+		return nil
+	case p.EventTicket != nil:
+		return p.EventTicket.GenericPass
+	case p.BoardingPass != nil:
+		return p.BoardingPass.GenericPass
+	case p.Coupon != nil:
+		return p.Coupon.GenericPass
+	case p.StoreCard != nil:
+		return p.StoreCard.GenericPass
+	case p.Generic != nil:
+		return p.Generic
+	default:
+		return nil
+	}
+}
+
+func sortedFieldKeys(fields map[string]sectionField) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// jsonProperties marshals v and splits the resulting object by key. A nil v has no properties.
+func jsonProperties(v interface{}) (map[string]json.RawMessage, error) {
+	ret := make(map[string]json.RawMessage)
+
+	switch t := v.(type) {
+	case *Pass:
+		if t == nil {
+			return ret, nil
+		}
+	case *SemanticTag:
+		if t == nil {
+			return ret, nil
+		}
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// sameJSON compares two values by their JSON representation, so numbers of different Go types with the same
+// value are considered equal.
+func sameJSON(a, b interface{}) bool {
+	ab, errA := json.Marshal(a)
+	bb, errB := json.Marshal(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+
+	return bytes.Equal(ab, bb)
+}
@@ -0,0 +1,189 @@
+package passkit
+
+import (
+	"testing"
+)
+
+func getDiffPasses() (*Pass, *Pass) {
+	oldPass := getBasicPass()
+	oldPass.Generic = &GenericPass{
+		PrimaryFields: []Field{{Key: "balance", Value: 10, ChangeMessage: "Balance is now %@"}},
+		BackFields:    []Field{{Key: "terms", Value: "v1"}},
+	}
+	oldPass.Semantics = &SemanticTag{EventName: "Concert"}
+
+	newPass := getBasicPass()
+	newPass.Generic = &GenericPass{
+		PrimaryFields: []Field{{Key: "balance", Value: 10, ChangeMessage: "Balance is now %@"}},
+		BackFields:    []Field{{Key: "terms", Value: "v1"}},
+	}
+	newPass.Semantics = &SemanticTag{EventName: "Concert"}
+	newPass.ExpirationDate = oldPass.ExpirationDate
+
+	return &oldPass, &newPass
+}
+
+func TestDiffPasses_NoChanges(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if d.HasChanges() {
+		t.Errorf("Passes should have no changes. Have: %v", d.Changes)
+	}
+}
+
+func TestDiffPasses_VisibleFieldChange(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+	newPass.Generic.PrimaryFields[0].Value = 20
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if len(d.Changes) != 1 {
+		t.Fatalf("Passes should have one change. Have: %v", d.Changes)
+	}
+
+	c := d.Changes[0]
+	if c.Kind != PassChangeKindField || c.Type != PassChangeModified || c.Key != "balance" || c.Section != "primaryFields" {
+		t.Errorf("Unexpected change %+v", c)
+	}
+
+	if !d.HasVisibleChanges() {
+		t.Errorf("Change to a field with a changeMessage should be visible")
+	}
+}
+
+func TestDiffPasses_SilentFieldChange(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+	newPass.Generic.BackFields[0].Value = "v2"
+	newPass.Generic.PrimaryFields[0].Label = "Balance"
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if len(d.Changes) != 2 {
+		t.Fatalf("Passes should have two changes. Have: %v", d.Changes)
+	}
+
+	if d.HasVisibleChanges() {
+		t.Errorf("Changes should not be visible. Have: %v", d.Changes)
+	}
+
+	d.ApplyChangeMessage(newPass, "Updated to %@")
+	if newPass.Generic.BackFields[0].ChangeMessage != "Updated to %@" {
+		t.Errorf("ChangeMessage should be set on the changed field")
+	}
+
+	if newPass.Generic.PrimaryFields[0].ChangeMessage != "Balance is now %@" {
+		t.Errorf("Existing ChangeMessage should not be replaced")
+	}
+
+	if !d.HasVisibleChanges() {
+		t.Errorf("Value change should be visible after applying the change message")
+	}
+}
+
+func TestDiffPasses_AddedAndRemovedFields(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+	newPass.Generic.BackFields = []Field{{Key: "contact", Value: "555-1234"}}
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if len(d.Changes) != 2 {
+		t.Fatalf("Passes should have two changes. Have: %v", d.Changes)
+	}
+
+	if d.Changes[0].Type != PassChangeRemoved || d.Changes[0].Key != "terms" {
+		t.Errorf("Field terms should be removed. Have: %+v", d.Changes[0])
+	}
+
+	if d.Changes[1].Type != PassChangeAdded || d.Changes[1].Key != "contact" {
+		t.Errorf("Field contact should be added. Have: %+v", d.Changes[1])
+	}
+
+	keys := d.ChangedFieldKeys()
+	if len(keys) != 1 || keys[0] != "contact" {
+		t.Errorf("Only contact should be reported as changed. Have: %v", keys)
+	}
+}
+
+func TestDiffPasses_PropertiesAndSemantics(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+	newPass.Voided = true
+	newPass.Semantics.EventName = "Opera"
+	newPass.Semantics.VenueName = "Hall"
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if len(d.Changes) != 3 {
+		t.Fatalf("Passes should have three changes. Have: %v", d.Changes)
+	}
+
+	if d.Changes[0].Kind != PassChangeKindProperty || d.Changes[0].Key != "voided" || d.Changes[0].Type != PassChangeAdded {
+		t.Errorf("Unexpected change %+v", d.Changes[0])
+	}
+
+	if d.Changes[1].Kind != PassChangeKindSemantic || d.Changes[1].Key != "eventName" || d.Changes[1].NewValue != "Opera" {
+		t.Errorf("Unexpected change %+v", d.Changes[1])
+	}
+
+	if d.Changes[2].Kind != PassChangeKindSemantic || d.Changes[2].Key != "venueName" || d.Changes[2].Type != PassChangeAdded {
+		t.Errorf("Unexpected change %+v", d.Changes[2])
+	}
+
+	if d.HasVisibleChanges() {
+		t.Errorf("Property changes should not be visible")
+	}
+}
+
+func TestDiffPasses_StyleChange(t *testing.T) {
+	oldPass, newPass := getDiffPasses()
+	newPass.Coupon = &Coupon{GenericPass: newPass.Generic}
+	newPass.Generic = nil
+
+	d, err := DiffPasses(oldPass, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	if len(d.Changes) != 2 {
+		t.Fatalf("Passes should have two changes. Have: %v", d.Changes)
+	}
+
+	if d.Changes[0].Key != "coupon" || d.Changes[0].Type != PassChangeAdded {
+		t.Errorf("Unexpected change %+v", d.Changes[0])
+	}
+
+	if d.Changes[1].Key != "generic" || d.Changes[1].Type != PassChangeRemoved {
+		t.Errorf("Unexpected change %+v", d.Changes[1])
+	}
+}
+
+func TestDiffPasses_NilPass(t *testing.T) {
+	_, newPass := getDiffPasses()
+
+	d, err := DiffPasses(nil, newPass)
+	if err != nil {
+		t.Fatalf("could not diff passes. %v", err)
+	}
+
+	for _, c := range d.Changes {
+		if c.Type != PassChangeAdded {
+			t.Errorf("All changes should be additions. Have: %+v", c)
+		}
+	}
+}
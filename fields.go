@@ -0,0 +1,117 @@
+package passkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	decimalAmountRegexp  = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+	jsonNumberRegexp     = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+	htmlTagRegexp        = regexp.MustCompile(`<[^>]*>`)
+	anchorOpenTagRegexp  = regexp.MustCompile(`^<a\s+href\s*=\s*("[^"<>]*"|'[^'<>]*')\s*>$`)
+	anchorCloseTagRegexp = regexp.MustCompile(`^</a\s*>$`)
+)
+
+// NewTextField creates a field displaying value as plain text.
+func NewTextField(key, label, value string) Field {
+	return Field{Key: key, Label: label, Value: value}
+}
+
+// NewDateField creates a field displaying t as a date, a time or both, depending on the styles. If neither style
+// is set the date is displayed with DateStyleMedium, so the field is always formatted as a date.
+func NewDateField(key, label string, t time.Time, dateStyle, timeStyle DateStyle) Field {
+	if string(dateStyle) == "" && string(timeStyle) == "" {
+		dateStyle = DateStyleMedium
+	}
+
+	return Field{Key: key, Label: label, Value: t, DateStyle: dateStyle, TimeStyle: timeStyle}
+}
+
+// NewCurrencyField creates a field displaying amount in the currency with the ISO 4217 currencyCode. The amount
+// is a decimal string, like "12.50", and is written to pass.json without its leading zeros, but otherwise as is, so
+// it is never rounded by a float conversion.
+func NewCurrencyField(key, label, amount, currencyCode string) (Field, error) {
+	if !decimalAmountRegexp.MatchString(amount) {
+		return Field{}, fmt.Errorf("currency field %q: amount %q is not a decimal number", key, amount)
+	}
+
+	if !isCurrencyCode(currencyCode) {
+		return Field{}, fmt.Errorf("currency field %q: invalid currency code %q", key, currencyCode)
+	}
+
+	return Field{Key: key, Label: label, Value: json.Number(trimLeadingZeros(amount)), CurrencyCode: currencyCode}, nil
+}
+
+// trimLeadingZeros removes the leading zeros of the integer part of a decimal amount, which JSON numbers can't
+// have, keeping the last one, so "007.50" becomes "7.50" and "00.50" becomes "0.50".
+func trimLeadingZeros(amount string) string {
+	sign, digits := "", amount
+	if rest, ok := strings.CutPrefix(amount, "-"); ok {
+		sign, digits = "-", rest
+	}
+
+	for len(digits) > 1 && digits[0] == '0' && digits[1] != '.' {
+		digits = digits[1:]
+	}
+
+	return sign + digits
+}
+
+// NewNumberField creates a field displaying value with the given number style.
+func NewNumberField(key, label string, value float64, style NumberStyle) Field {
+	return Field{Key: key, Label: label, Value: value, NumberStyle: style}
+}
+
+// NewAttributedField creates a field whose attributedValue is the given HTML. Wallet only supports <a href>
+// tags in attributed values, so any other markup is rejected. The plain text of the HTML is used as the value,
+// which is what devices that don't support attributed values display.
+func NewAttributedField(key, label, htmlValue string) (Field, error) {
+	if err := validateAttributedValue(htmlValue); err != nil {
+		return Field{}, fmt.Errorf("attributed field %q: %w", key, err)
+	}
+
+	text := html.UnescapeString(htmlTagRegexp.ReplaceAllString(htmlValue, ""))
+	return Field{Key: key, Label: label, Value: text, AttributedValue: htmlValue}, nil
+}
+
+// validateAttributedValue checks that the only tags in the value are non nested <a href> links.
+func validateAttributedValue(value string) error {
+	open := false
+	for _, tag := range htmlTagRegexp.FindAllString(value, -1) {
+		switch {
+		case anchorOpenTagRegexp.MatchString(tag):
+			if open {
+				return fmt.Errorf("nested links are not allowed")
+			}
+			open = true
+		case anchorCloseTagRegexp.MatchString(tag):
+			if !open {
+				return fmt.Errorf("closing </a> tag without an opening tag")
+			}
+			open = false
+		default:
+			return fmt.Errorf("tag %s is not allowed, only <a href> links are supported", tag)
+		}
+	}
+
+	if open {
+		return fmt.Errorf("link is not closed")
+	}
+
+	// Stray angle brackets would be rendered as broken markup
+	if strings.ContainsAny(htmlTagRegexp.ReplaceAllString(value, ""), "<>") {
+		return fmt.Errorf("unbalanced angle brackets")
+	}
+
+	return nil
+}
+
+// isDecimalNumber reports whether n can be written to pass.json as a number.
+func isDecimalNumber(n json.Number) bool {
+	return jsonNumberRegexp.MatchString(string(n))
+}
@@ -0,0 +1,138 @@
+package passkit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestNewDateField(t *testing.T) {
+	now := time.Now()
+
+	f := NewDateField("date", "Date", now, DateStyleShort, DateStyleNone)
+	if !f.IsValid() {
+		t.Errorf("Field should be valid. Reason: %v", f.GetValidationErrors())
+	}
+
+	if f.DateStyle != DateStyleShort || f.TimeStyle != DateStyleNone {
+		t.Errorf("Date styles were not set. %+v", f)
+	}
+
+	f = NewDateField("date", "Date", now, "", "")
+	if f.DateStyle != DateStyleMedium {
+		t.Errorf("DateStyle should default to medium. Have: %v", f.DateStyle)
+	}
+}
+
+func TestNewCurrencyField(t *testing.T) {
+	f, err := NewCurrencyField("price", "Price", "19.99", "EUR")
+	if err != nil {
+		t.Fatalf("could not create currency field. %v", err)
+	}
+
+	if !f.IsValid() {
+		t.Errorf("Field should be valid. Reason: %v", f.GetValidationErrors())
+	}
+
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatalf("could not marshal field. %v", err)
+	}
+
+	expected := `{"key":"price","label":"Price","value":19.99,"currencyCode":"EUR"}`
+	if string(b) != expected {
+		t.Errorf("Currency field JSON did not match. Have: %s", b)
+	}
+
+	for _, amount := range []string{"", "1,5", "1.", "abc", "1e5", "NaN"} {
+		if _, err := NewCurrencyField("price", "Price", amount, "EUR"); err == nil {
+			t.Errorf("Amount %q should be invalid", amount)
+		}
+	}
+
+	for amount, expected := range map[string]json.Number{"007": "7", "-00.50": "-0.50", "0": "0", "10.05": "10.05"} {
+		f, err := NewCurrencyField("price", "Price", amount, "EUR")
+		if err != nil {
+			t.Fatalf("could not create currency field with amount %q. %v", amount, err)
+		}
+
+		if f.Value != expected || !f.IsValid() {
+			t.Errorf("Amount %q should be stored as %q. Have: %v %v", amount, expected, f.Value, f.GetValidationErrors())
+		}
+
+		if _, err := json.Marshal(f); err != nil {
+			t.Errorf("could not marshal field with amount %q. %v", amount, err)
+		}
+	}
+
+	for _, code := range []string{"", "eur", "EURO", "E1R"} {
+		if _, err := NewCurrencyField("price", "Price", "1", code); err == nil {
+			t.Errorf("Currency code %q should be invalid", code)
+		}
+	}
+}
+
+func TestNewNumberField(t *testing.T) {
+	f := NewNumberField("percent", "Percent", 0.5, NumberStylePercent)
+	if !f.IsValid() {
+		t.Errorf("Field should be valid. Reason: %v", f.GetValidationErrors())
+	}
+
+	if f.NumberStyle != NumberStylePercent || f.CurrencyCode != "" {
+		t.Errorf("Number field was not built as expected. %+v", f)
+	}
+}
+
+func TestNewAttributedField(t *testing.T) {
+	f, err := NewAttributedField("profile", "Profile", `Edit <a href="https://example.com/profile?a=1&amp;b=2">your profile</a> &amp; more`)
+	if err != nil {
+		t.Fatalf("could not create attributed field. %v", err)
+	}
+
+	if !f.IsValid() {
+		t.Errorf("Field should be valid. Reason: %v", f.GetValidationErrors())
+	}
+
+	if f.Value != "Edit your profile & more" {
+		t.Errorf("Value should be the plain text. Have: %q", f.Value)
+	}
+
+	invalid := []string{
+		"<b>bold</b>",
+		"<a>no href</a>",
+		"<a href='x'>not closed",
+		"closed</a>",
+		"<a href='x'><a href='y'>nested</a></a>",
+		"<script>alert(1)</script>",
+		"1 < 2",
+	}
+	for _, v := range invalid {
+		if _, err := NewAttributedField("key", "Label", v); err == nil {
+			t.Errorf("Attributed value %q should be invalid", v)
+		}
+	}
+}
+
+func TestField_InvalidAttributedValue(t *testing.T) {
+	field := getBasicField()
+	field.Value = "value"
+	field.AttributedValue = "<img src='x'>"
+
+	t.Logf("%d, %v", len(field.GetValidationErrors()), field.GetValidationErrors())
+	if field.IsValid() {
+		t.Errorf("Field should be invalid")
+	}
+
+	if len(field.GetValidationErrors()) != 1 {
+		t.Errorf("Field should have one error. Have: %v", len(field.GetValidationErrors()))
+	}
+}
+
+func TestField_InvalidJSONNumber(t *testing.T) {
+	field := getBasicField()
+	field.Value = json.Number("1,5")
+
+	if field.IsValid() {
+		t.Errorf("Field should be invalid")
+	}
+}
@@ -3,18 +3,17 @@ package passkit
 import (
 	"archive/zip"
 	"bytes"
-	"crypto/sha1"
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 )
 
 type fileSigner struct {
+	options signerOptions
 }
 
-func NewFileBasedSigner() Signer {
-	return &fileSigner{}
+func NewFileBasedSigner(opts ...SignerOption) Signer {
+	return &fileSigner{options: newSignerOptions(opts)}
 }
 
 func (f *fileSigner) CreateSignedAndZippedPassArchive(p *Pass, t PassTemplate, i *SigningInformation) (PassArchive, error) {
@@ -22,6 +21,11 @@ func (f *fileSigner) CreateSignedAndZippedPassArchive(p *Pass, t PassTemplate, i
 }
 
 func (f *fileSigner) CreateSignedAndZippedPersonalizedPassArchive(p *Pass, pz *Personalization, t PassTemplate, i *SigningInformation) (PassArchive, error) {
+	i, err := f.options.signingInformation(p.PassTypeIdentifier, i)
+	if err != nil {
+		return nil, err
+	}
+
 	dir, err := os.MkdirTemp("", "pass")
 	if err != nil {
 		return nil, err
@@ -31,28 +35,27 @@ func (f *fileSigner) CreateSignedAndZippedPersonalizedPassArchive(p *Pass, pz *P
 		return nil, err
 	}
 
-	if err := f.createPassJSONFile(p, dir); err != nil {
+	templateFiles, err := loadDir(dir)
+	if err != nil {
 		return nil, err
 	}
 
-	if pz != nil {
-		if err := f.createPersonalizationJSONFile(pz, dir); err != nil {
-			return nil, err
-		}
+	if err := f.options.checkFindings(p, validatePassBundleFiles(p, pz, templateFiles)); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
 	}
 
-	mfst, err := f.createManifestJSONFile(dir)
-	if err != nil {
+	if err := f.createPassJSONFile(p, dir); err != nil {
 		return nil, err
 	}
 
-	signedMfst, err := signManifestFile(mfst, i)
-	if err != nil {
-		return nil, err
+	if pz != nil {
+		if err := f.createPersonalizationJSONFile(pz, dir); err != nil {
+			return nil, err
+		}
 	}
 
-	err = os.WriteFile(signatureFileName, signedMfst, 0644)
-	if err != nil {
+	if err := f.createManifestAndSignatureFiles(dir, i); err != nil {
 		return nil, err
 	}
 
@@ -100,14 +103,10 @@ func (f *fileSigner) CreatePassBundleArchive(passArchives ...PassArchive) (PassB
 }
 
 func (f *fileSigner) SignManifestFile(manifestJson []byte, i *SigningInformation) ([]byte, error) {
-	return signManifestFile(manifestJson, i)
+	return signManifestFile(manifestJson, i, f.options)
 }
 
 func (f *fileSigner) createPassJSONFile(p *Pass, tmpDir string) error {
-	if !p.IsValid() {
-		return fmt.Errorf("%v", p.GetValidationErrors())
-	}
-
 	b, err := p.toJSON()
 	if err != nil {
 		return err
@@ -125,38 +124,24 @@ func (f *fileSigner) createPersonalizationJSONFile(pz *Personalization, tmpDir s
 	return os.WriteFile(filepath.Join(tmpDir, personalizationJsonFileName), b, 0644)
 }
 
-func (f *fileSigner) createManifestJSONFile(tmpDir string) ([]byte, error) {
-	m, err := f.hashFiles(tmpDir)
-	if err != nil {
-		return nil, err
-	}
-
-	bm, err := json.Marshal(m)
-	if err != nil {
-		return nil, err
-	}
-
-	err = os.WriteFile(manifestJsonFileName, bm, 0644)
+// createManifestAndSignatureFiles writes the manifest of the files in tmpDir, and its signature, to tmpDir.
+func (f *fileSigner) createManifestAndSignatureFiles(tmpDir string, i *SigningInformation) error {
+	files, err := loadDir(tmpDir)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	return bm, nil
-}
-
-func (f *fileSigner) hashFiles(tmpDir string) (map[string]string, error) {
-	files, err := loadDir(tmpDir)
-	if err != nil {
-		return nil, err
+	if err := signBundleFiles(files, i, f.options); err != nil {
+		return err
 	}
 
-	ret := make(map[string]string)
-	for name, data := range files {
-		hash := sha1.Sum(data)
-		ret[filepath.Base(name)] = fmt.Sprintf("%x", hash)
+	for _, name := range []string{manifestJsonFileName, signatureFileName} {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), files[name], 0644); err != nil {
+			return err
+		}
 	}
 
-	return ret, nil
+	return nil
 }
 
 func (f *fileSigner) createZipFile(tmpDir string) ([]byte, error) {
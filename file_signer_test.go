@@ -1,5 +1,10 @@
 package passkit
 
+import (
+	"encoding/json"
+	"testing"
+)
+
 //
 //js, err := ioutil.ReadFile(filepath.Join("test", "pass2.json"))
 //if err != nil {
@@ -8,3 +13,31 @@ package passkit
 //
 //var pass Pass
 //err = json.Unmarshal(js, &pass)
+
+func TestFileSigner_CreateSignedAndZippedPassArchive(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+	template.AddFileBytesLocalized(BundleIconRetinaHD, "en", []byte("icon"))
+
+	z, err := NewFileBasedSigner().CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	files := readZip(t, z)
+	for _, name := range []string{passJsonFileName, manifestJsonFileName, signatureFileName, BundleIcon, "en.lproj/" + BundleIconRetinaHD} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Archive should contain %s", name)
+		}
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(files[manifestJsonFileName], &manifest); err != nil {
+		t.Fatalf("could not read manifest. %v", err)
+	}
+
+	if _, ok := manifest["en.lproj/"+BundleIconRetinaHD]; !ok {
+		t.Errorf("Manifest should use the path of localized files. Have: %v", manifest)
+	}
+}
@@ -0,0 +1,393 @@
+package passkit
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GoogleWalletSaveURL is the URL prefix of the "Save to Google Wallet" links, followed by a JWT created with
+// SignGoogleWalletSaveJWT.
+const GoogleWalletSaveURL = "https://pay.google.com/gp/v/save/"
+
+type GoogleWalletObjectType string
+
+const (
+	GoogleWalletEventTicketObject GoogleWalletObjectType = "eventTicketObject"
+	GoogleWalletFlightObject      GoogleWalletObjectType = "flightObject"
+	GoogleWalletLoyaltyObject     GoogleWalletObjectType = "loyaltyObject"
+	GoogleWalletOfferObject       GoogleWalletObjectType = "offerObject"
+	GoogleWalletGenericObject     GoogleWalletObjectType = "genericObject"
+)
+
+var (
+	googleWalletIDRegexp = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+	googleWalletBarcodeTypes = map[BarcodeFormat]string{
+		BarcodeFormatQR:      "QR_CODE",
+		BarcodeFormatPDF417:  "PDF_417",
+		BarcodeFormatAztec:   "AZTEC",
+		BarcodeFormatCode128: "CODE_128",
+	}
+)
+
+// GoogleWalletObject is a Google Wallet pass object, as described in
+// https://developers.google.com/wallet/reference/rest. Type tells which object it is, the fields that don't apply
+// to the type are left empty.
+type GoogleWalletObject struct {
+	Type GoogleWalletObjectType `json:"-"`
+
+	ID                 string                          `json:"id"`
+	ClassID            string                          `json:"classId"`
+	State              string                          `json:"state"`
+	Barcode            *GoogleWalletBarcode            `json:"barcode,omitempty"`
+	HexBackgroundColor string                          `json:"hexBackgroundColor,omitempty"`
+	Locations          []GoogleWalletLatLongPoint      `json:"locations,omitempty"`
+	TextModulesData    []GoogleWalletTextModule        `json:"textModulesData,omitempty"`
+	ValidTimeInterval  *GoogleWalletTimeInterval       `json:"validTimeInterval,omitempty"`
+	CardTitle          *GoogleWalletLocalizedString    `json:"cardTitle,omitempty"`
+	Header             *GoogleWalletLocalizedString    `json:"header,omitempty"`
+	Subheader          *GoogleWalletLocalizedString    `json:"subheader,omitempty"`
+	TicketHolderName   string                          `json:"ticketHolderName,omitempty"`
+	TicketNumber       string                          `json:"ticketNumber,omitempty"`
+	SeatInfo           *GoogleWalletEventSeat          `json:"seatInfo,omitempty"`
+	PassengerName      string                          `json:"passengerName,omitempty"`
+	ReservationInfo    *GoogleWalletReservationInfo    `json:"reservationInfo,omitempty"`
+	BoardingAndSeating *GoogleWalletBoardingAndSeating `json:"boardingAndSeatingInfo,omitempty"`
+	AccountID          string                          `json:"accountId,omitempty"`
+	LoyaltyPoints      *GoogleWalletLoyaltyPoints      `json:"loyaltyPoints,omitempty"`
+}
+
+type GoogleWalletBarcode struct {
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+	AlternateText string `json:"alternateText,omitempty"`
+}
+
+type GoogleWalletLatLongPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type GoogleWalletTextModule struct {
+	ID     string `json:"id,omitempty"`
+	Header string `json:"header,omitempty"`
+	Body   string `json:"body"`
+}
+
+type GoogleWalletTimeInterval struct {
+	Start *GoogleWalletDateTime `json:"start,omitempty"`
+	End   *GoogleWalletDateTime `json:"end,omitempty"`
+}
+
+type GoogleWalletDateTime struct {
+	Date string `json:"date"`
+}
+
+type GoogleWalletLocalizedString struct {
+	DefaultValue GoogleWalletTranslatedString `json:"defaultValue"`
+}
+
+type GoogleWalletTranslatedString struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+type GoogleWalletEventSeat struct {
+	Seat    *GoogleWalletLocalizedString `json:"seat,omitempty"`
+	Row     *GoogleWalletLocalizedString `json:"row,omitempty"`
+	Section *GoogleWalletLocalizedString `json:"section,omitempty"`
+	Gate    *GoogleWalletLocalizedString `json:"gate,omitempty"`
+}
+
+type GoogleWalletReservationInfo struct {
+	ConfirmationCode string `json:"confirmationCode,omitempty"`
+}
+
+type GoogleWalletBoardingAndSeating struct {
+	BoardingGroup    string `json:"boardingGroup,omitempty"`
+	SeatNumber       string `json:"seatNumber,omitempty"`
+	SeatClass        string `json:"seatClass,omitempty"`
+	BoardingPosition string `json:"boardingPosition,omitempty"`
+	SequenceNumber   string `json:"sequenceNumber,omitempty"`
+	BoardingDoor     string `json:"boardingDoor,omitempty"`
+}
+
+type GoogleWalletLoyaltyPoints struct {
+	Label   string                     `json:"label,omitempty"`
+	Balance GoogleWalletLoyaltyBalance `json:"balance"`
+}
+
+type GoogleWalletLoyaltyBalance struct {
+	String string `json:"string"`
+}
+
+// GoogleWalletOption configures ExportGoogleWalletObject.
+type GoogleWalletOption func(o *googleWalletOptions)
+
+type googleWalletOptions struct {
+	classID  string
+	language string
+}
+
+// WithGoogleWalletClassID sets the class of the object, without the issuer ID prefix. By default the pass type
+// identifier is used.
+func WithGoogleWalletClassID(classID string) GoogleWalletOption {
+	return func(o *googleWalletOptions) {
+		o.classID = classID
+	}
+}
+
+// WithGoogleWalletLanguage sets the language of the localized strings of the object, en-US by default.
+func WithGoogleWalletLanguage(language string) GoogleWalletOption {
+	return func(o *googleWalletOptions) {
+		o.language = language
+	}
+}
+
+// ExportGoogleWalletObject converts a pass into the equivalent Google Wallet object of the issuer: event tickets
+// into eventTicketObject, air boarding passes into flightObject, store cards into loyaltyObject, coupons into
+// offerObject, and the rest into genericObject. The barcode, background color, locations and expiration date are
+// mapped for every type, and the back fields become text modules. The class of the object has to be created in
+// Google Wallet separately.
+func ExportGoogleWalletObject(p *Pass, issuerID string, opts ...GoogleWalletOption) (*GoogleWalletObject, error) {
+	o := googleWalletOptions{language: "en-US"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if issuerID == "" {
+		return nil, errors.New("GoogleWallet: the issuer ID is required")
+	}
+
+	gp := p.genericPass()
+	if gp == nil {
+		return nil, errors.New("GoogleWallet: the pass has no style")
+	}
+
+	classID := o.classID
+	if classID == "" {
+		classID = p.PassTypeIdentifier
+	}
+
+	obj := &GoogleWalletObject{
+		ID:      issuerID + "." + googleWalletID(p.SerialNumber),
+		ClassID: issuerID + "." + googleWalletID(classID),
+		State:   "ACTIVE",
+	}
+
+	if p.Voided {
+		obj.State = "INACTIVE"
+	}
+
+	if len(p.Barcodes) > 0 {
+		b := p.Barcodes[0]
+		t, ok := googleWalletBarcodeTypes[b.Format]
+		if !ok {
+			return nil, fmt.Errorf("GoogleWallet: unsupported barcode format %q", b.Format)
+		}
+		obj.Barcode = &GoogleWalletBarcode{Type: t, Value: b.Message, AlternateText: b.AltText}
+	}
+
+	if p.BackgroundColor != "" {
+		hex, err := rgbToHex(p.BackgroundColor)
+		if err != nil {
+			return nil, err
+		}
+		obj.HexBackgroundColor = hex
+	}
+
+	for _, l := range p.Locations {
+		obj.Locations = append(obj.Locations, GoogleWalletLatLongPoint{Latitude: l.Latitude, Longitude: l.Longitude})
+	}
+
+	for _, f := range gp.BackFields {
+		obj.TextModulesData = append(obj.TextModulesData, GoogleWalletTextModule{ID: f.Key, Header: f.Label, Body: fieldValueString(f)})
+	}
+
+	if p.ExpirationDate != nil {
+		obj.ValidTimeInterval = &GoogleWalletTimeInterval{End: &GoogleWalletDateTime{Date: p.ExpirationDate.Format(time.RFC3339)}}
+	}
+
+	localized := func(v string) *GoogleWalletLocalizedString {
+		if v == "" {
+			return nil
+		}
+		return &GoogleWalletLocalizedString{DefaultValue: GoogleWalletTranslatedString{Language: o.language, Value: v}}
+	}
+
+	semantics := p.Semantics
+	if semantics == nil {
+		semantics = &SemanticTag{}
+	}
+
+	switch {
+	case p.EventTicket != nil:
+		obj.Type = GoogleWalletEventTicketObject
+		obj.TicketHolderName = semantics.AttendeeName
+		seat := GoogleWalletEventSeat{}
+		if p.TicketDetail != nil {
+			obj.TicketNumber = p.TicketDetail.TicketNumber
+			seat = GoogleWalletEventSeat{
+				Seat:    localized(p.TicketDetail.TicketSeat),
+				Row:     localized(p.TicketDetail.TicketRow),
+				Section: localized(p.TicketDetail.TicketSection),
+				Gate:    localized(p.TicketDetail.TicketGate),
+			}
+		} else if len(semantics.Seats) > 0 {
+			s := semantics.Seats[0]
+			seat = GoogleWalletEventSeat{Seat: localized(s.SeatNumber), Row: localized(s.SeatRow), Section: localized(s.SeatSection)}
+		}
+		if seat != (GoogleWalletEventSeat{}) {
+			obj.SeatInfo = &seat
+		}
+	case p.BoardingPass != nil && p.BoardingPass.TransitType == TransitTypeAir:
+		obj.Type = GoogleWalletFlightObject
+		obj.PassengerName = personName(semantics.PassengerName)
+		if obj.PassengerName == "" {
+			return nil, errors.New("GoogleWallet: flight objects require the passengerName semantic tag")
+		}
+		obj.ReservationInfo = &GoogleWalletReservationInfo{ConfirmationCode: semantics.ConfirmationNumber}
+		boarding := GoogleWalletBoardingAndSeating{BoardingGroup: semantics.BoardingGroup, SequenceNumber: semantics.BoardingSequenceNumber}
+		if len(semantics.Seats) > 0 {
+			boarding.SeatNumber = semantics.Seats[0].SeatNumber
+			boarding.SeatClass = semantics.Seats[0].SeatType
+		}
+		if boarding != (GoogleWalletBoardingAndSeating{}) {
+			obj.BoardingAndSeating = &boarding
+		}
+	case p.StoreCard != nil:
+		obj.Type = GoogleWalletLoyaltyObject
+		obj.AccountID = semantics.MembershipProgramNumber
+		if obj.AccountID == "" && obj.Barcode != nil {
+			obj.AccountID = obj.Barcode.Value
+		}
+		if len(gp.PrimaryFields) > 0 {
+			f := gp.PrimaryFields[0]
+			obj.LoyaltyPoints = &GoogleWalletLoyaltyPoints{Label: f.Label, Balance: GoogleWalletLoyaltyBalance{String: fieldValueString(f)}}
+		}
+	case p.Coupon != nil:
+		obj.Type = GoogleWalletOfferObject
+	default:
+		obj.Type = GoogleWalletGenericObject
+		obj.CardTitle = localized(p.OrganizationName)
+		header := p.Description
+		if len(gp.PrimaryFields) > 0 {
+			header = fieldValueString(gp.PrimaryFields[0])
+			obj.Subheader = localized(gp.PrimaryFields[0].Label)
+		}
+		obj.Header = localized(header)
+		if obj.CardTitle == nil || obj.Header == nil {
+			return nil, errors.New("GoogleWallet: generic objects require an organization name and a description or primary field")
+		}
+	}
+
+	return obj, nil
+}
+
+// SignGoogleWalletSaveJWT creates the RS256 signed JWT of a "Save to Google Wallet" link for the objects, signed
+// with the RSA key of the service account. origins are the domains allowed to show the save button, and may be
+// empty for links.
+func SignGoogleWalletSaveJWT(serviceAccountEmail string, key *rsa.PrivateKey, origins []string, objects ...*GoogleWalletObject) (string, error) {
+	if len(objects) == 0 {
+		return "", errors.New("GoogleWallet: at least one object is required")
+	}
+
+	payload := make(map[string][]*GoogleWalletObject)
+	for _, obj := range objects {
+		if obj.Type == "" {
+			return "", fmt.Errorf("GoogleWallet: object %s has no type", obj.ID)
+		}
+		payload[string(obj.Type)+"s"] = append(payload[string(obj.Type)+"s"], obj)
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(struct {
+		Iss     string                           `json:"iss"`
+		Aud     string                           `json:"aud"`
+		Typ     string                           `json:"typ"`
+		Iat     int64                            `json:"iat"`
+		Origins []string                         `json:"origins"`
+		Payload map[string][]*GoogleWalletObject `json:"payload"`
+	}{serviceAccountEmail, "google", "savetowallet", time.Now().Unix(), append([]string{}, origins...), payload})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// googleWalletID replaces the characters not allowed in Google Wallet identifiers with underscores.
+func googleWalletID(s string) string {
+	return googleWalletIDRegexp.ReplaceAllString(s, "_")
+}
+
+// rgbToHex converts a pass color, like rgb(23, 187, 82), into a hex color, like #17bb52.
+func rgbToHex(color string) (string, error) {
+	if errs := validateRGBColor("color", color); len(errs) > 0 {
+		return "", errors.New(errs[0])
+	}
+
+	m := rgbColorRegexp.FindStringSubmatch(color)
+	hex := "#"
+	for _, c := range m[1:] {
+		v, _ := strconv.Atoi(c)
+		hex += fmt.Sprintf("%02x", v)
+	}
+
+	return hex, nil
+}
+
+// fieldValueString formats the value of a field as text.
+func fieldValueString(f Field) string {
+	switch v := f.Value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.Format(time.RFC3339)
+	case nil:
+		return ""
+	default:
+		s := fmt.Sprint(v)
+		if f.CurrencyCode != "" {
+			s += " " + f.CurrencyCode
+		}
+		return s
+	}
+}
+
+// personName formats the name components of a person as a single name.
+func personName(n *SemanticTagPersonNameComponents) string {
+	if n == nil {
+		return ""
+	}
+
+	var parts []string
+	for _, p := range []string{n.NamePrefix, n.GivenName, n.MiddleName, n.FamilyName, n.NameSuffix} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,189 @@
+package passkit
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestExportGoogleWalletObject_EventTicket(t *testing.T) {
+	p, err := getBasicPassBuilder().
+		BackgroundColorHex("#17bb52").
+		Location(Location{Latitude: 4.6, Longitude: -74.1}).
+		EventTicket(func(b *FieldsBuilder) {
+			b.Primary().Text("event", "Event", "The Concert")
+			b.Back().Text("terms", "Terms", "No refunds")
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("could not build pass. %v", err)
+	}
+	p.TicketDetail = &TicketDetail{TicketNumber: "T-1", TicketSeat: "12", TicketRow: "F"}
+
+	obj, err := ExportGoogleWalletObject(p, "3388000000012345678")
+	if err != nil {
+		t.Fatalf("could not export pass. %v", err)
+	}
+
+	if obj.Type != GoogleWalletEventTicketObject || obj.ID != "3388000000012345678.1234" || obj.ClassID != "3388000000012345678.pass.com.example" || obj.State != "ACTIVE" {
+		t.Errorf("unexpected object %+v", obj)
+	}
+
+	if obj.Barcode == nil || obj.Barcode.Type != "QR_CODE" || obj.Barcode.Value != "1234" {
+		t.Errorf("barcode not mapped, got %+v", obj.Barcode)
+	}
+
+	if obj.HexBackgroundColor != "#17bb52" {
+		t.Errorf("background color not mapped, got %q", obj.HexBackgroundColor)
+	}
+
+	if len(obj.Locations) != 1 || obj.Locations[0].Latitude != 4.6 {
+		t.Errorf("locations not mapped, got %v", obj.Locations)
+	}
+
+	if len(obj.TextModulesData) != 1 || obj.TextModulesData[0] != (GoogleWalletTextModule{ID: "terms", Header: "Terms", Body: "No refunds"}) {
+		t.Errorf("back fields not mapped to text modules, got %v", obj.TextModulesData)
+	}
+
+	if obj.TicketNumber != "T-1" || obj.SeatInfo == nil || obj.SeatInfo.Seat.DefaultValue.Value != "12" || obj.SeatInfo.Section != nil {
+		t.Errorf("ticket details not mapped, got %q %+v", obj.TicketNumber, obj.SeatInfo)
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("could not marshal object. %v", err)
+	}
+
+	if strings.Contains(string(b), "passengerName") || !strings.Contains(string(b), `"classId"`) {
+		t.Errorf("unexpected JSON %s", b)
+	}
+}
+
+func TestExportGoogleWalletObject_Types(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *PassBuilder
+		expected GoogleWalletObjectType
+	}{
+		{"flight", getBasicPassBuilder().BoardingPass(TransitTypeAir, func(b *FieldsBuilder) {}).Semantics(&SemanticTag{PassengerName: &SemanticTagPersonNameComponents{GivenName: "Ada", FamilyName: "Lovelace"}, ConfirmationNumber: "ABC123"}), GoogleWalletFlightObject},
+		{"train", getBasicPassBuilder().BoardingPass(TransitTypeTrain, func(b *FieldsBuilder) {}), GoogleWalletGenericObject},
+		{"loyalty", getBasicPassBuilder().StoreCard(func(b *FieldsBuilder) { b.Primary().Text("points", "Points", "120") }), GoogleWalletLoyaltyObject},
+		{"offer", getBasicPassBuilder().Coupon(func(b *FieldsBuilder) {}), GoogleWalletOfferObject},
+		{"generic", getBasicPassBuilder().Generic(func(b *FieldsBuilder) {}), GoogleWalletGenericObject},
+	}
+
+	for _, test := range tests {
+		p, err := test.builder.Build()
+		if err != nil {
+			t.Fatalf("%s: could not build pass. %v", test.name, err)
+		}
+
+		obj, err := ExportGoogleWalletObject(p, "issuer", WithGoogleWalletClassID("my class"))
+		if err != nil {
+			t.Fatalf("%s: could not export pass. %v", test.name, err)
+		}
+
+		if obj.Type != test.expected || obj.ClassID != "issuer.my_class" {
+			t.Errorf("%s: expected %s, got %s %s", test.name, test.expected, obj.Type, obj.ClassID)
+		}
+
+		switch obj.Type {
+		case GoogleWalletFlightObject:
+			if obj.PassengerName != "Ada Lovelace" || obj.ReservationInfo.ConfirmationCode != "ABC123" {
+				t.Errorf("%s: flight details not mapped, got %+v", test.name, obj)
+			}
+		case GoogleWalletLoyaltyObject:
+			if obj.AccountID != "1234" || obj.LoyaltyPoints == nil || obj.LoyaltyPoints.Balance.String != "120" {
+				t.Errorf("%s: loyalty details not mapped, got %+v", test.name, obj)
+			}
+		case GoogleWalletGenericObject:
+			if obj.CardTitle == nil || obj.Header == nil || obj.Header.DefaultValue.Value != "test" {
+				t.Errorf("%s: generic title and header not mapped, got %+v", test.name, obj)
+			}
+		}
+	}
+}
+
+func TestExportGoogleWalletObject_Errors(t *testing.T) {
+	p, err := getBasicPassBuilder().BoardingPass(TransitTypeAir, func(b *FieldsBuilder) {}).Build()
+	if err != nil {
+		t.Fatalf("could not build pass. %v", err)
+	}
+
+	if _, err := ExportGoogleWalletObject(p, "issuer"); err == nil {
+		t.Errorf("flights without passenger name should not be exported")
+	}
+
+	if _, err := ExportGoogleWalletObject(p, ""); err == nil {
+		t.Errorf("the issuer ID should be required")
+	}
+
+	if _, err := ExportGoogleWalletObject(&Pass{}, "issuer"); err == nil {
+		t.Errorf("passes without style should not be exported")
+	}
+}
+
+func TestSignGoogleWalletSaveJWT(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	p, err := getBasicPassBuilder().Coupon(func(b *FieldsBuilder) {}).Build()
+	if err != nil {
+		t.Fatalf("could not build pass. %v", err)
+	}
+
+	obj, err := ExportGoogleWalletObject(p, "issuer")
+	if err != nil {
+		t.Fatalf("could not export pass. %v", err)
+	}
+
+	token, err := SignGoogleWalletSaveJWT("wallet@example.iam.gserviceaccount.com", key, []string{"https://example.com"}, obj)
+	if err != nil {
+		t.Fatalf("could not sign JWT. %v", err)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a compact JWT, got %q", token)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("could not decode signature. %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid JWT signature. %v", err)
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		t.Fatalf("could not decode claims. %v", err)
+	}
+
+	var claims struct {
+		Iss     string                          `json:"iss"`
+		Aud     string                          `json:"aud"`
+		Typ     string                          `json:"typ"`
+		Payload map[string][]GoogleWalletObject `json:"payload"`
+	}
+	if err := json.Unmarshal(b, &claims); err != nil {
+		t.Fatalf("could not read claims. %v", err)
+	}
+
+	if claims.Iss != "wallet@example.iam.gserviceaccount.com" || claims.Aud != "google" || claims.Typ != "savetowallet" {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if offers := claims.Payload["offerObjects"]; len(offers) != 1 || offers[0].ID != "issuer.1234" {
+		t.Errorf("the object should be in the payload, got %v", claims.Payload)
+	}
+}
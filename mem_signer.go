@@ -3,16 +3,15 @@ package passkit
 import (
 	"archive/zip"
 	"bytes"
-	"crypto/sha1"
-	"encoding/json"
 	"fmt"
 )
 
 type memorySigner struct {
+	options signerOptions
 }
 
-func NewMemoryBasedSigner() Signer {
-	return &memorySigner{}
+func NewMemoryBasedSigner(opts ...SignerOption) Signer {
+	return &memorySigner{options: newSignerOptions(opts)}
 }
 
 func (m *memorySigner) CreateSignedAndZippedPassArchive(p *Pass, t PassTemplate, i *SigningInformation) (PassArchive, error) {
@@ -20,14 +19,19 @@ func (m *memorySigner) CreateSignedAndZippedPassArchive(p *Pass, t PassTemplate,
 }
 
 func (m *memorySigner) CreateSignedAndZippedPersonalizedPassArchive(p *Pass, pz *Personalization, t PassTemplate, i *SigningInformation) (PassArchive, error) {
+	i, err := m.options.signingInformation(p.PassTypeIdentifier, i)
+	if err != nil {
+		return nil, err
+	}
+
 	originalFiles, err := t.GetAllFiles()
 	if err != nil {
 		return nil, err
 	}
-	files := m.makeFilesCopy(originalFiles)
+	files := copyFiles(originalFiles)
 
-	if !p.IsValid() {
-		return nil, fmt.Errorf("%v", p.GetValidationErrors())
+	if err := m.options.checkFindings(p, validatePassBundleFiles(p, pz, files)); err != nil {
+		return nil, err
 	}
 
 	pb, err := p.toJSON()
@@ -38,10 +42,6 @@ func (m *memorySigner) CreateSignedAndZippedPersonalizedPassArchive(p *Pass, pz
 	files[passJsonFileName] = pb
 
 	if pz != nil {
-		if !pz.IsValid() {
-			return nil, fmt.Errorf("%v", pz.GetValidationErrors())
-		}
-
 		pzb, err := pz.toJSON()
 		if err != nil {
 			return nil, err
@@ -50,26 +50,11 @@ func (m *memorySigner) CreateSignedAndZippedPersonalizedPassArchive(p *Pass, pz
 		files[personalizationJsonFileName] = pzb
 	}
 
-	msftHash, err := m.hashFiles(files)
-	if err != nil {
-		return nil, err
-	}
-
-	mfst, err := json.Marshal(msftHash)
-	if err != nil {
+	if err := signBundleFiles(files, i, m.options); err != nil {
 		return nil, err
 	}
 
-	files[manifestJsonFileName] = mfst
-
-	signedMfst, err := signManifestFile(mfst, i)
-	if err != nil {
-		return nil, err
-	}
-
-	files[signatureFileName] = signedMfst
-
-	z, err := m.createZipFile(files)
+	z, err := createZipArchive(files)
 	if err != nil {
 		return nil, err
 	}
@@ -111,50 +96,5 @@ func (m *memorySigner) CreatePassBundleArchive(passArchives ...PassArchive) (Pas
 }
 
 func (m *memorySigner) SignManifestFile(manifestJson []byte, i *SigningInformation) ([]byte, error) {
-	return signManifestFile(manifestJson, i)
-}
-
-func (m *memorySigner) hashFiles(files map[string][]byte) (map[string]string, error) {
-	ret := make(map[string]string)
-	for name, data := range files {
-		// Skip .DS_Store files.
-		if name == ".DS_Store" {
-			continue
-		}
-		hash := sha1.Sum(data)
-		ret[name] = fmt.Sprintf("%x", hash)
-	}
-
-	return ret, nil
-}
-
-func (m *memorySigner) createZipFile(files map[string][]byte) ([]byte, error) {
-	buf := new(bytes.Buffer)
-	w := zip.NewWriter(buf)
-
-	for name, data := range files {
-		f, err := w.Create(name)
-		if err != nil {
-			return nil, err
-		}
-		_, err = f.Write(data)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if err := w.Close(); err != nil {
-		return nil, err
-	}
-
-	return buf.Bytes(), nil
-}
-
-func (m *memorySigner) makeFilesCopy(files map[string][]byte) map[string][]byte {
-	filesCopy := make(map[string][]byte, len(files))
-	for k := range files {
-		filesCopy[k] = files[k]
-	}
-
-	return filesCopy
+	return signManifestFile(manifestJson, i, m.options)
 }
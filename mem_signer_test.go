@@ -1 +1,146 @@
 package passkit
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.mozilla.org/pkcs7"
+)
+
+func TestMemorySigner_CreateSignedAndZippedPassArchive(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+	template.AddFileBytes(BundleIconRetinaHD, []byte("icon"))
+
+	var warnings ValidationFindings
+	signer := NewMemoryBasedSigner(WithWarningHandler(func(p *Pass, w ValidationFindings) {
+		warnings = w
+	}))
+
+	z, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(z), int64(len(z)))
+	if err != nil {
+		t.Fatalf("could not read archive. %v", err)
+	}
+
+	names := make(map[string]bool)
+	for _, f := range r.File {
+		names[f.Name] = true
+	}
+
+	for _, name := range []string{passJsonFileName, manifestJsonFileName, signatureFileName, BundleIcon} {
+		if !names[name] {
+			t.Errorf("Archive should contain %s. Have: %v", name, names)
+		}
+	}
+
+	if len(warnings) != 0 {
+		t.Errorf("Pass should have no warnings. Have: %v", warnings)
+	}
+}
+
+func TestMemorySigner_Warnings(t *testing.T) {
+	pass := getBasicPass()
+	pass.Barcodes = []Barcode{{Format: BarcodeFormatCode128, Message: "1234", MessageEncoding: "utf-8"}}
+
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+	template.AddFileBytesLocalized(BundleLogoRetina, "en", []byte("logo"))
+
+	var warnings ValidationFindings
+	signer := NewMemoryBasedSigner(WithWarningHandler(func(p *Pass, w ValidationFindings) {
+		warnings = w
+	}))
+
+	if _, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t)); err != nil {
+		t.Fatalf("Warnings should not make signing fail. %v", err)
+	}
+
+	t.Logf("%d, %v", len(warnings), warnings)
+	if len(warnings.Warnings()) != 3 {
+		t.Errorf("Pass should have three warnings. Have: %v", len(warnings))
+	}
+}
+
+func TestMemorySigner_Errors(t *testing.T) {
+	pass := getBasicPass()
+	pass.SerialNumber = ""
+
+	signer := NewMemoryBasedSigner()
+	_, err := signer.CreateSignedAndZippedPassArchive(&pass, NewInMemoryPassTemplate(), newTestSigningInformation(t))
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Error should be a ValidationError. Have: %v", err)
+	}
+
+	if len(validationErr.Errors) != 1 {
+		t.Errorf("Pass should have one error. Have: %v", validationErr.Errors)
+	}
+}
+
+func TestMemorySigner_HashAlgorithms(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	tests := []struct {
+		name         string
+		opts         []SignerOption
+		manifestHash int
+		digest       asn1.ObjectIdentifier
+	}{
+		{"default", nil, 40, pkcs7.OIDDigestAlgorithmSHA1},
+		{"sha256 manifest", []SignerOption{WithManifestHash(crypto.SHA256)}, 64, pkcs7.OIDDigestAlgorithmSHA1},
+		{"sha256 digest", []SignerOption{WithDigestAlgorithm(crypto.SHA256)}, 40, pkcs7.OIDDigestAlgorithmSHA256},
+		{"sha512", []SignerOption{WithManifestHash(crypto.SHA512), WithDigestAlgorithm(crypto.SHA512)}, 128, pkcs7.OIDDigestAlgorithmSHA512},
+	}
+
+	for _, test := range tests {
+		z, err := NewMemoryBasedSigner(test.opts...).CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+		if err != nil {
+			t.Fatalf("%s: could not sign pass. %v", test.name, err)
+		}
+
+		files := readZip(t, z)
+		var manifest map[string]string
+		if err := json.Unmarshal(files[manifestJsonFileName], &manifest); err != nil {
+			t.Fatalf("%s: could not read manifest. %v", test.name, err)
+		}
+
+		if len(manifest[passJsonFileName]) != test.manifestHash {
+			t.Errorf("%s: manifest hash should have %d hex digits, got %q", test.name, test.manifestHash, manifest[passJsonFileName])
+		}
+
+		p7, err := pkcs7.Parse(files[signatureFileName])
+		if err != nil {
+			t.Fatalf("%s: could not parse signature. %v", test.name, err)
+		}
+
+		if alg := p7.Signers[0].DigestAlgorithm.Algorithm; !alg.Equal(test.digest) {
+			t.Errorf("%s: signature digest should be %v, got %v", test.name, test.digest, alg)
+		}
+	}
+}
+
+func TestMemorySigner_UnsupportedHash(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	for _, opt := range []SignerOption{WithManifestHash(crypto.MD5), WithDigestAlgorithm(crypto.MD5)} {
+		if _, err := NewMemoryBasedSigner(opt).CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t)); err == nil {
+			t.Errorf("Signing with MD5 should fail")
+		}
+	}
+}
@@ -0,0 +1,396 @@
+package passkit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	orderJsonFileName         = "order.json"
+	orderTypeIdentifierPrefix = "order."
+)
+
+type OrderStatus string
+type FulfillmentType string
+type FulfillmentStatus string
+type PaymentStatus string
+
+// OrderTypeEcommerce is the only order type supported by Wallet.
+const OrderTypeEcommerce = "ecommerce"
+
+const (
+	OrderStatusOpen      OrderStatus = "open"
+	OrderStatusCompleted OrderStatus = "completed"
+	OrderStatusCancelled OrderStatus = "cancelled"
+
+	FulfillmentTypeShipping FulfillmentType = "shipping"
+	FulfillmentTypePickup   FulfillmentType = "pickup"
+
+	FulfillmentStatusProcessing     FulfillmentStatus = "processing"
+	FulfillmentStatusOnTheWay       FulfillmentStatus = "onTheWay"
+	FulfillmentStatusShipped        FulfillmentStatus = "shipped"
+	FulfillmentStatusOutForDelivery FulfillmentStatus = "outForDelivery"
+	FulfillmentStatusDelivered      FulfillmentStatus = "delivered"
+	FulfillmentStatusReadyForPickup FulfillmentStatus = "readyForPickup"
+	FulfillmentStatusPickedUp       FulfillmentStatus = "pickedUp"
+	FulfillmentStatusIssue          FulfillmentStatus = "issue"
+	FulfillmentStatusCancelled      FulfillmentStatus = "cancelled"
+
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusPaid       PaymentStatus = "paid"
+	PaymentStatusRefunded   PaymentStatus = "refunded"
+)
+
+var (
+	fulfillmentStatuses = map[FulfillmentType][]FulfillmentStatus{
+		FulfillmentTypeShipping: {FulfillmentStatusProcessing, FulfillmentStatusOnTheWay, FulfillmentStatusShipped, FulfillmentStatusOutForDelivery, FulfillmentStatusDelivered, FulfillmentStatusIssue, FulfillmentStatusCancelled},
+		FulfillmentTypePickup:   {FulfillmentStatusProcessing, FulfillmentStatusReadyForPickup, FulfillmentStatusPickedUp, FulfillmentStatusIssue, FulfillmentStatusCancelled},
+	}
+)
+
+// Order Representation of https://developer.apple.com/documentation/walletorders/order
+type Order struct {
+	SchemaVersion       int                    `json:"schemaVersion"`
+	OrderType           string                 `json:"orderType"`
+	OrderTypeIdentifier string                 `json:"orderTypeIdentifier"`
+	OrderIdentifier     string                 `json:"orderIdentifier"`
+	OrderNumber         string                 `json:"orderNumber,omitempty"`
+	OrderManagementURL  string                 `json:"orderManagementURL"`
+	WebServiceURL       string                 `json:"webServiceURL,omitempty"`
+	AuthenticationToken string                 `json:"authenticationToken,omitempty"`
+	CreatedAt           *time.Time             `json:"createdAt"`
+	UpdatedAt           *time.Time             `json:"updatedAt"`
+	Status              OrderStatus            `json:"status"`
+	StatusDescription   string                 `json:"statusDescription,omitempty"`
+	Merchant            *OrderMerchant         `json:"merchant"`
+	Customer            *OrderCustomer         `json:"customer,omitempty"`
+	LineItems           []OrderLineItem        `json:"lineItems,omitempty"`
+	Fulfillments        []OrderFulfillment     `json:"fulfillments,omitempty"`
+	Payment             *OrderPayment          `json:"payment,omitempty"`
+	UserInfo            map[string]interface{} `json:"userInfo,omitempty"`
+}
+
+// NewOrder creates an open ecommerce order with the current schema version.
+func NewOrder() *Order {
+	return &Order{SchemaVersion: 1, OrderType: OrderTypeEcommerce, Status: OrderStatusOpen}
+}
+
+func (o *Order) toJSON() ([]byte, error) {
+	return json.Marshal(o)
+}
+
+func (o *Order) IsValid() bool {
+	return len(o.GetValidationErrors()) == 0
+}
+
+func (o *Order) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if o.SchemaVersion != 1 || o.OrderType == "" || o.OrderTypeIdentifier == "" || o.OrderIdentifier == "" || o.OrderManagementURL == "" ||
+		o.CreatedAt == nil || o.UpdatedAt == nil || o.Status == "" || o.Merchant == nil {
+		validationErrors = append(validationErrors, "Order: Not all required Fields are set. SchemaVersion, OrderType, OrderTypeIdentifier, OrderIdentifier, OrderManagementURL, CreatedAt, UpdatedAt, Status, Merchant")
+	}
+
+	if o.OrderType != "" && o.OrderType != OrderTypeEcommerce {
+		validationErrors = append(validationErrors, fmt.Sprintf("Order: The orderType %q is not supported, it must be %q", o.OrderType, OrderTypeEcommerce))
+	}
+
+	if o.OrderTypeIdentifier != "" && !strings.HasPrefix(o.OrderTypeIdentifier, orderTypeIdentifierPrefix) {
+		validationErrors = append(validationErrors, fmt.Sprintf("Order: The orderTypeIdentifier %q must start with %q", o.OrderTypeIdentifier, orderTypeIdentifierPrefix))
+	}
+
+	switch o.Status {
+	case "", OrderStatusOpen, OrderStatusCompleted, OrderStatusCancelled:
+	default:
+		validationErrors = append(validationErrors, fmt.Sprintf("Order: Unknown status %q", o.Status))
+	}
+
+	if o.CreatedAt != nil && o.UpdatedAt != nil && o.UpdatedAt.Before(*o.CreatedAt) {
+		validationErrors = append(validationErrors, "Order: UpdatedAt must not be before CreatedAt")
+	}
+
+	validationErrors = append(validationErrors, validateURL("Order", "orderManagementURL", o.OrderManagementURL, "https")...)
+	validationErrors = append(validationErrors, validateURL("Order", "webServiceURL", o.WebServiceURL, "https")...)
+
+	if o.WebServiceURL != "" && len(o.AuthenticationToken) < 16 {
+		validationErrors = append(validationErrors, "Order: The authenticationToken must be at least 16 characters long when using a webServiceURL")
+	}
+
+	if o.Merchant != nil && !o.Merchant.IsValid() {
+		validationErrors = append(validationErrors, o.Merchant.GetValidationErrors()...)
+	}
+
+	for _, li := range o.LineItems {
+		if !li.IsValid() {
+			validationErrors = append(validationErrors, li.GetValidationErrors()...)
+		}
+	}
+
+	identifiers := make(map[string]bool)
+	for _, f := range o.Fulfillments {
+		if !f.IsValid() {
+			validationErrors = append(validationErrors, f.GetValidationErrors()...)
+		}
+
+		if f.FulfillmentIdentifier != "" && identifiers[f.FulfillmentIdentifier] {
+			validationErrors = append(validationErrors, fmt.Sprintf("Order: The fulfillment identifier %q is used more than once", f.FulfillmentIdentifier))
+		}
+		identifiers[f.FulfillmentIdentifier] = true
+	}
+
+	if o.Payment != nil && !o.Payment.IsValid() {
+		validationErrors = append(validationErrors, o.Payment.GetValidationErrors()...)
+	}
+
+	return validationErrors
+}
+
+// images returns the names of the bundle images the order refers to.
+func (o *Order) images() []string {
+	var images []string
+	if o.Merchant != nil && o.Merchant.Logo != "" {
+		images = append(images, o.Merchant.Logo)
+	}
+
+	for _, li := range o.LineItems {
+		if li.Image != "" {
+			images = append(images, li.Image)
+		}
+	}
+
+	for _, f := range o.Fulfillments {
+		for _, li := range f.LineItems {
+			if li.Image != "" {
+				images = append(images, li.Image)
+			}
+		}
+	}
+
+	return images
+}
+
+// OrderMerchant Representation of https://developer.apple.com/documentation/walletorders/merchant
+type OrderMerchant struct {
+	MerchantIdentifier string `json:"merchantIdentifier"`
+	DisplayName        string `json:"displayName"`
+	URL                string `json:"url"`
+	Logo               string `json:"logo,omitempty"`
+	BusinessChatURL    string `json:"businessChatURL,omitempty"`
+}
+
+func (m *OrderMerchant) IsValid() bool {
+	return len(m.GetValidationErrors()) == 0
+}
+
+func (m *OrderMerchant) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if m.MerchantIdentifier == "" || m.DisplayName == "" || m.URL == "" {
+		validationErrors = append(validationErrors, "OrderMerchant: Not all required Fields are set. MerchantIdentifier, DisplayName, URL")
+	}
+
+	validationErrors = append(validationErrors, validateURL("OrderMerchant", "url", m.URL, "https")...)
+	validationErrors = append(validationErrors, validateURL("OrderMerchant", "businessChatURL", m.BusinessChatURL, "https")...)
+
+	return validationErrors
+}
+
+// OrderCustomer Representation of https://developer.apple.com/documentation/walletorders/customer
+type OrderCustomer struct {
+	GivenName    string `json:"givenName,omitempty"`
+	FamilyName   string `json:"familyName,omitempty"`
+	EmailAddress string `json:"emailAddress,omitempty"`
+	PhoneNumber  string `json:"phoneNumber,omitempty"`
+}
+
+// OrderCurrencyAmount Representation of https://developer.apple.com/documentation/walletorders/currencyamount
+type OrderCurrencyAmount struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+func (a *OrderCurrencyAmount) IsValid() bool {
+	return len(a.GetValidationErrors()) == 0
+}
+
+func (a *OrderCurrencyAmount) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if !decimalAmountRegexp.MatchString(a.Amount) {
+		validationErrors = append(validationErrors, fmt.Sprintf("OrderCurrencyAmount: The amount %q is not a decimal number", a.Amount))
+	}
+
+	if !isCurrencyCode(a.Currency) {
+		validationErrors = append(validationErrors, fmt.Sprintf("OrderCurrencyAmount: Invalid ISO 4217 currency code %q", a.Currency))
+	}
+
+	return validationErrors
+}
+
+// OrderLineItem Representation of https://developer.apple.com/documentation/walletorders/lineitem
+type OrderLineItem struct {
+	Title    string               `json:"title"`
+	Subtitle string               `json:"subtitle,omitempty"`
+	Quantity float64              `json:"quantity"`
+	Price    *OrderCurrencyAmount `json:"price,omitempty"`
+	Image    string               `json:"image,omitempty"`
+	GTIN     string               `json:"gtin,omitempty"`
+	SKU      string               `json:"sku,omitempty"`
+}
+
+func (li *OrderLineItem) IsValid() bool {
+	return len(li.GetValidationErrors()) == 0
+}
+
+func (li *OrderLineItem) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if li.Title == "" {
+		validationErrors = append(validationErrors, "OrderLineItem: Not all required Fields are set: title")
+	}
+
+	if li.Quantity <= 0 {
+		validationErrors = append(validationErrors, fmt.Sprintf("OrderLineItem: The quantity of %q must be greater than 0", li.Title))
+	}
+
+	if li.Price != nil && !li.Price.IsValid() {
+		validationErrors = append(validationErrors, li.Price.GetValidationErrors()...)
+	}
+
+	return validationErrors
+}
+
+// OrderFulfillment Representation of https://developer.apple.com/documentation/walletorders/shippingfulfillment and
+// https://developer.apple.com/documentation/walletorders/pickupfulfillment. The fields that only apply to one type
+// of fulfillment are ignored by the other.
+type OrderFulfillment struct {
+	FulfillmentType       FulfillmentType   `json:"fulfillmentType"`
+	FulfillmentIdentifier string            `json:"fulfillmentIdentifier"`
+	Status                FulfillmentStatus `json:"status"`
+	StatusDescription     string            `json:"statusDescription,omitempty"`
+	LineItems             []OrderLineItem   `json:"lineItems,omitempty"`
+	Notes                 string            `json:"notes,omitempty"`
+
+	// Shipping
+	Carrier             string     `json:"carrier,omitempty"`
+	TrackingNumber      string     `json:"trackingNumber,omitempty"`
+	TrackingURL         string     `json:"trackingURL,omitempty"`
+	EstimatedDeliveryAt *time.Time `json:"estimatedDeliveryAt,omitempty"`
+	DeliveredAt         *time.Time `json:"deliveredAt,omitempty"`
+
+	// Pickup
+	PickupAt    *time.Time    `json:"pickupAt,omitempty"`
+	PickedUpAt  *time.Time    `json:"pickedUpAt,omitempty"`
+	Address     *OrderAddress `json:"address,omitempty"`
+	DisplayName string        `json:"displayName,omitempty"`
+}
+
+func (f *OrderFulfillment) IsValid() bool {
+	return len(f.GetValidationErrors()) == 0
+}
+
+func (f *OrderFulfillment) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if f.FulfillmentType == "" || f.FulfillmentIdentifier == "" || f.Status == "" {
+		validationErrors = append(validationErrors, "OrderFulfillment: Not all required Fields are set. FulfillmentType, FulfillmentIdentifier, Status")
+	}
+
+	statuses, ok := fulfillmentStatuses[f.FulfillmentType]
+	if f.FulfillmentType != "" && !ok {
+		validationErrors = append(validationErrors, fmt.Sprintf("OrderFulfillment: Unknown fulfillment type %q", f.FulfillmentType))
+	}
+
+	if ok && f.Status != "" {
+		known := false
+		for _, s := range statuses {
+			if s == f.Status {
+				known = true
+			}
+		}
+
+		if !known {
+			validationErrors = append(validationErrors, fmt.Sprintf("OrderFulfillment: The status %q is not valid for %s fulfillments", f.Status, f.FulfillmentType))
+		}
+	}
+
+	validationErrors = append(validationErrors, validateURL("OrderFulfillment", "trackingURL", f.TrackingURL, "https")...)
+
+	for _, li := range f.LineItems {
+		if !li.IsValid() {
+			validationErrors = append(validationErrors, li.GetValidationErrors()...)
+		}
+	}
+
+	return validationErrors
+}
+
+// OrderAddress Representation of https://developer.apple.com/documentation/walletorders/address
+type OrderAddress struct {
+	AddressLines       []string `json:"addressLines,omitempty"`
+	Locality           string   `json:"locality,omitempty"`
+	PostalCode         string   `json:"postalCode,omitempty"`
+	AdministrativeArea string   `json:"administrativeArea,omitempty"`
+	CountryCode        string   `json:"countryCode,omitempty"`
+}
+
+// OrderPayment Representation of https://developer.apple.com/documentation/walletorders/payment
+type OrderPayment struct {
+	Total          *OrderCurrencyAmount `json:"total"`
+	Status         PaymentStatus        `json:"status"`
+	SummaryItems   []OrderSummaryItem   `json:"summaryItems,omitempty"`
+	PaymentMethods []OrderPaymentMethod `json:"paymentMethods,omitempty"`
+}
+
+func (p *OrderPayment) IsValid() bool {
+	return len(p.GetValidationErrors()) == 0
+}
+
+func (p *OrderPayment) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if p.Total == nil || p.Status == "" {
+		validationErrors = append(validationErrors, "OrderPayment: Not all required Fields are set. Total, Status")
+	}
+
+	switch p.Status {
+	case "", PaymentStatusPending, PaymentStatusAuthorized, PaymentStatusPaid, PaymentStatusRefunded:
+	default:
+		validationErrors = append(validationErrors, fmt.Sprintf("OrderPayment: Unknown status %q", p.Status))
+	}
+
+	if p.Total != nil && !p.Total.IsValid() {
+		validationErrors = append(validationErrors, p.Total.GetValidationErrors()...)
+	}
+
+	for _, si := range p.SummaryItems {
+		if si.Label == "" || si.Value == nil {
+			validationErrors = append(validationErrors, "OrderSummaryItem: Not all required Fields are set. Label, Value")
+			continue
+		}
+
+		if !si.Value.IsValid() {
+			validationErrors = append(validationErrors, si.Value.GetValidationErrors()...)
+		}
+
+		if p.Total != nil && si.Value.Currency != p.Total.Currency {
+			validationErrors = append(validationErrors, fmt.Sprintf("OrderSummaryItem: The currency of %q must be the currency of the total, %s", si.Label, p.Total.Currency))
+		}
+	}
+
+	return validationErrors
+}
+
+// OrderSummaryItem Representation of https://developer.apple.com/documentation/walletorders/payment/summaryitem
+type OrderSummaryItem struct {
+	Label string               `json:"label"`
+	Value *OrderCurrencyAmount `json:"value"`
+}
+
+// OrderPaymentMethod Representation of https://developer.apple.com/documentation/walletorders/paymentmethod
+type OrderPaymentMethod struct {
+	DisplayName string `json:"displayName"`
+}
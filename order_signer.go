@@ -0,0 +1,75 @@
+package passkit
+
+import (
+	"crypto"
+	"fmt"
+)
+
+type OrderArchive []byte
+
+// OrderSigner creates signed .order archives, the Apple Wallet order equivalent of a .pkpass archive.
+type OrderSigner interface {
+	CreateSignedAndZippedOrderArchive(o *Order, t PassTemplate, i *SigningInformation) (OrderArchive, error)
+}
+
+type orderSigner struct {
+	options signerOptions
+}
+
+// NewOrderSigner creates an OrderSigner that keeps the archive contents in memory. The template holds the images
+// the order refers to, like the merchant logo, with the same layout as a pass template. Unlike passes, orders use
+// SHA-256 for both the manifest hash and the signature digest by default.
+func NewOrderSigner(opts ...SignerOption) OrderSigner {
+	return &orderSigner{options: applySignerOptions(signerOptions{manifestHash: crypto.SHA256, digestAlgorithm: crypto.SHA256}, opts)}
+}
+
+func (s *orderSigner) CreateSignedAndZippedOrderArchive(o *Order, t PassTemplate, i *SigningInformation) (OrderArchive, error) {
+	i, err := s.options.signingInformation(o.OrderTypeIdentifier, i)
+	if err != nil {
+		return nil, err
+	}
+
+	originalFiles, err := t.GetAllFiles()
+	if err != nil {
+		return nil, err
+	}
+	files := copyFiles(originalFiles)
+
+	if err := validateOrderBundleFiles(o, files).Err(); err != nil {
+		return nil, err
+	}
+
+	ob, err := o.toJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	files[orderJsonFileName] = ob
+
+	if err := signBundleFiles(files, i, s.options); err != nil {
+		return nil, err
+	}
+
+	return createZipArchive(files)
+}
+
+// ValidateOrderBundle returns the problems found in the order and in the template it would be signed with.
+func ValidateOrderBundle(o *Order, t PassTemplate) (ValidationFindings, error) {
+	files, err := t.GetAllFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return validateOrderBundleFiles(o, files), nil
+}
+
+func validateOrderBundleFiles(o *Order, files map[string][]byte) ValidationFindings {
+	validationErrors := o.GetValidationErrors()
+	for _, image := range o.images() {
+		if _, ok := files[image]; !ok {
+			validationErrors = append(validationErrors, fmt.Sprintf("Template: The image %s referenced by the order is missing", image))
+		}
+	}
+
+	return newValidationFindings(validationErrors, nil)
+}
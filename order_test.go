@@ -0,0 +1,147 @@
+package passkit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+func getBasicOrder() *Order {
+	created := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	updated := created.Add(time.Hour)
+
+	o := NewOrder()
+	o.OrderTypeIdentifier = "order.com.example"
+	o.OrderIdentifier = "1234"
+	o.OrderManagementURL = "https://example.com/orders/1234"
+	o.CreatedAt = &created
+	o.UpdatedAt = &updated
+	o.Merchant = &OrderMerchant{MerchantIdentifier: "merchant.com.example", DisplayName: "Example", URL: "https://example.com", Logo: "logo.png"}
+	o.LineItems = []OrderLineItem{{Title: "Shoes", Quantity: 1, Price: &OrderCurrencyAmount{Amount: "50.00", Currency: "EUR"}}}
+	o.Fulfillments = []OrderFulfillment{{
+		FulfillmentType:       FulfillmentTypeShipping,
+		FulfillmentIdentifier: "f1",
+		Status:                FulfillmentStatusOnTheWay,
+		TrackingURL:           "https://example.com/track/1",
+	}}
+	o.Payment = &OrderPayment{
+		Total:        &OrderCurrencyAmount{Amount: "55.00", Currency: "EUR"},
+		Status:       PaymentStatusPaid,
+		SummaryItems: []OrderSummaryItem{{Label: "Shipping", Value: &OrderCurrencyAmount{Amount: "5.00", Currency: "EUR"}}},
+	}
+
+	return o
+}
+
+func TestOrder_Valid(t *testing.T) {
+	o := getBasicOrder()
+	if !o.IsValid() {
+		t.Errorf("Order should be valid. Reason: %v", o.GetValidationErrors())
+	}
+}
+
+func TestOrder_Invalid(t *testing.T) {
+	o := getBasicOrder()
+	o.OrderTypeIdentifier = "com.example"
+	o.Status = "lost"
+	o.Merchant.URL = "http://example.com"
+
+	if len(o.GetValidationErrors()) != 3 {
+		t.Errorf("Order should have 3 errors, got %v", o.GetValidationErrors())
+	}
+
+	o = NewOrder()
+	if o.IsValid() {
+		t.Errorf("Empty order should be invalid")
+	}
+}
+
+func TestOrder_OrderType(t *testing.T) {
+	o := getBasicOrder()
+
+	b, err := json.Marshal(o)
+	if err != nil {
+		t.Fatalf("could not marshal order. %v", err)
+	}
+
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("could not unmarshal order. %v", err)
+	}
+
+	if m["orderType"] != "ecommerce" {
+		t.Errorf("order.json should have the ecommerce orderType, got %v", m["orderType"])
+	}
+
+	for _, orderType := range []string{"", "retail"} {
+		o.OrderType = orderType
+		if len(o.GetValidationErrors()) != 1 {
+			t.Errorf("Order with orderType %q should have one error, got %v", orderType, o.GetValidationErrors())
+		}
+	}
+}
+
+func TestOrder_InvalidItemsAndFulfillments(t *testing.T) {
+	o := getBasicOrder()
+	o.LineItems[0].Quantity = 0
+	o.Fulfillments = append(o.Fulfillments, OrderFulfillment{FulfillmentType: FulfillmentTypePickup, FulfillmentIdentifier: "f1", Status: FulfillmentStatusOnTheWay})
+	o.Payment.SummaryItems[0].Value.Currency = "USD"
+
+	if len(o.GetValidationErrors()) != 4 {
+		t.Errorf("Order should have 4 errors, got %v", o.GetValidationErrors())
+	}
+}
+
+func TestOrderSigner_CreateSignedAndZippedOrderArchive(t *testing.T) {
+	o := getBasicOrder()
+	template := NewInMemoryPassTemplate()
+
+	signer := NewOrderSigner()
+	if _, err := signer.CreateSignedAndZippedOrderArchive(o, template, newTestSigningInformation(t)); err == nil {
+		t.Errorf("Order without the merchant logo in the template should not be signed")
+	}
+
+	template.AddFileBytes("logo.png", []byte("logo"))
+	z, err := signer.CreateSignedAndZippedOrderArchive(o, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign order. %v", err)
+	}
+
+	files := readZip(t, z)
+	for _, name := range []string{orderJsonFileName, manifestJsonFileName, signatureFileName, "logo.png"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Archive should contain %s", name)
+		}
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(files[manifestJsonFileName], &manifest); err != nil {
+		t.Fatalf("could not read manifest. %v", err)
+	}
+
+	if len(manifest[orderJsonFileName]) != 64 {
+		t.Errorf("Order manifest should use SHA-256 hashes, got %q", manifest[orderJsonFileName])
+	}
+
+	p7, err := pkcs7.Parse(files[signatureFileName])
+	if err != nil {
+		t.Fatalf("could not parse signature. %v", err)
+	}
+	p7.Content = files[manifestJsonFileName]
+	if err := p7.Verify(); err != nil {
+		t.Errorf("Signature should match the manifest. %v", err)
+	}
+}
+
+func readZip(t *testing.T, z []byte) map[string][]byte {
+	t.Helper()
+
+	files, err := ReadArchiveFiles(z)
+	if err != nil {
+		t.Fatalf("could not read archive. %v", err)
+	}
+
+	return files
+}
@@ -1,8 +1,18 @@
 package passkit
 
 import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -18,9 +28,14 @@ type NumberStyle string
 type PassPersonalizationField string
 type TransitType string
 type EventType string
+type StyleScheme string
+type UpcomingPassType string
 
 const (
 	expectedAuthTokenLen = 16
+	maxNFCMessageBytes   = 64
+	maxBeacons           = 10
+	maxLocations         = 10
 
 	TextAlignmentLeft    TextAlignment = "PKTextAlignmentLeft"
 	TextAlignmentCenter  TextAlignment = "PKTextAlignmentCenter"
@@ -67,10 +82,22 @@ const (
 	EventTypeConvention      EventType = "PKEventTypeConvention"
 	EventTypeWorkshop        EventType = "PKEventTypeWorkshop"
 	EventTypeSocialGathering EventType = "PKEventTypeSocialGathering"
+
+	StyleSchemePosterEventTicket StyleScheme = "posterEventTicket"
+	StyleSchemeEventTicket       StyleScheme = "eventTicket"
+
+	UpcomingPassTypeEvent UpcomingPassType = "event"
 )
 
 var (
 	BarcodeTypesBeforeIos9 = [3]BarcodeFormat{BarcodeFormatQR, BarcodeFormatPDF417, BarcodeFormatAztec}
+
+	// Android package names, like com.example.app
+	googlePlayIDRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)+$`)
+	// Amazon Appstore ASINs, like B004SBT8TY
+	amazonIDRegexp = regexp.MustCompile(`^[A-Z0-9]{10}$`)
+
+	uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
 )
 
 type Validateable interface {
@@ -78,6 +105,16 @@ type Validateable interface {
 	GetValidationErrors() []string
 }
 
+// ValidationError is returned when a pass, or one of its parts, is not valid. Errors holds the messages returned
+// by GetValidationErrors.
+type ValidationError struct {
+	Errors []string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v", e.Errors)
+}
+
 // Pass Representation of https://developer.apple.com/documentation/walletpasses/pass
 type Pass struct {
 	FormatVersion              int                    `json:"formatVersion,omitempty"`
@@ -104,6 +141,7 @@ type Pass struct {
 	Generic                    *GenericPass           `json:"generic,omitempty"`
 	AppLaunchURL               string                 `json:"appLaunchURL,omitempty"`
 	AssociatedStoreIdentifiers []int64                `json:"associatedStoreIdentifiers,omitempty"`
+	AssociatedApps             []PWAssociatedApp      `json:"associatedApps,omitempty"`
 	UserInfo                   map[string]interface{} `json:"userInfo,omitempty"`
 	MaxDistance                int64                  `json:"maxDistance,omitempty"`
 	RelevantDate               *time.Time             `json:"relevantDate,omitempty"`
@@ -116,13 +154,20 @@ type Pass struct {
 	EventDetail                *EventDetail           `json:"eventDetail,omitempty"`
 	VenueDetail                *VenueDetail           `json:"venueDetail,omitempty"`
 	TicketDetail               *TicketDetail          `json:"ticketDetail,omitempty"`
-	PreferredStyleSchemes      []string               `json:"preferredStyleSchemes,omitempty"`
+	PreferredStyleSchemes      []StyleScheme          `json:"preferredStyleSchemes,omitempty"`
 	UpcomingPassInformation    []UpcomingPass         `json:"upcomingPassInformation,omitempty"`
 	BagPolicyURL               string                 `json:"bagPolicyURL,omitempty"`
 	OrderFoodURL               string                 `json:"orderFoodURL,omitempty"`
 
 	//Private
-	associatedApps []PWAssociatedApp
+	allowHTTPWebService bool
+}
+
+// SetAllowHTTPWebService allows the WebServiceURL to use plain HTTP, which is only accepted by devices with the
+// "Allow HTTP Services" developer setting enabled. Use it for local development only; production passes must use
+// HTTPS.
+func (p *Pass) SetAllowHTTPWebService(allow bool) {
+	p.allowHTTPWebService = allow
 }
 
 func (p *Pass) SetForegroundColorHex(hex string) error {
@@ -236,6 +281,25 @@ func (p *Pass) GetValidationErrors() []string {
 			"Pass: The authenticationToken needs to be at least "+strconv.Itoa(expectedAuthTokenLen)+" characters long")
 	}
 
+	if p.allowHTTPWebService {
+		validationErrors = append(validationErrors, validateURL("Pass", "webServiceURL", p.WebServiceURL, "https", "http")...)
+	} else {
+		validationErrors = append(validationErrors, validateURL("Pass", "webServiceURL", p.WebServiceURL, "https")...)
+	}
+
+	if strings.TrimSpace(p.PassTypeIdentifier) != "" && !strings.HasPrefix(p.PassTypeIdentifier, passTypeIdentifierPrefix) {
+		validationErrors = append(validationErrors, fmt.Sprintf("Pass: The passTypeIdentifier %q must start with %q", p.PassTypeIdentifier, passTypeIdentifierPrefix))
+	}
+
+	validationErrors = append(validationErrors, validateRGBColor("foregroundColor", p.ForegroundColor)...)
+	validationErrors = append(validationErrors, validateRGBColor("backgroundColor", p.BackgroundColor)...)
+	validationErrors = append(validationErrors, validateRGBColor("labelColor", p.LabelColor)...)
+	validationErrors = append(validationErrors, validateRGBColor("footerBackgroundColor", p.FooterBackgroundColor)...)
+
+	validationErrors = append(validationErrors, validateURL("Pass", "appLaunchURL", p.AppLaunchURL)...)
+	validationErrors = append(validationErrors, validateURL("Pass", "bagPolicyURL", p.BagPolicyURL, "https", "http")...)
+	validationErrors = append(validationErrors, validateURL("Pass", "orderFoodURL", p.OrderFoodURL, "https", "http")...)
+
 	if p.EventTicket != nil && !p.EventTicket.IsValid() {
 		validationErrors = append(validationErrors, p.EventTicket.GetValidationErrors()...)
 	} else if p.BoardingPass != nil && !p.BoardingPass.IsValid() {
@@ -260,6 +324,26 @@ func (p *Pass) GetValidationErrors() []string {
 		}
 	}
 
+	if len(p.Beacons) > maxBeacons {
+		validationErrors = append(validationErrors, fmt.Sprintf("Pass: A pass can have at most %d beacons. Have: %d", maxBeacons, len(p.Beacons)))
+	}
+
+	if len(p.Locations) > maxLocations {
+		validationErrors = append(validationErrors, fmt.Sprintf("Pass: A pass can have at most %d locations. Have: %d", maxLocations, len(p.Locations)))
+	}
+
+	if p.MaxDistance < 0 {
+		validationErrors = append(validationErrors, "Pass: The maxDistance can't be negative")
+	}
+
+	if p.Locations != nil {
+		for _, l := range p.Locations {
+			if !l.IsValid() {
+				validationErrors = append(validationErrors, l.GetValidationErrors()...)
+			}
+		}
+	}
+
 	if p.Beacons != nil {
 		for _, b := range p.Beacons {
 			if !b.IsValid() {
@@ -276,16 +360,34 @@ func (p *Pass) GetValidationErrors() []string {
 		}
 	}
 
+	for _, a := range p.AssociatedApps {
+		if !a.IsValid() {
+			validationErrors = append(validationErrors, a.GetValidationErrors()...)
+		}
+	}
+
+	if p.Nfc != nil && !p.Nfc.IsValid() {
+		validationErrors = append(validationErrors, p.Nfc.GetValidationErrors()...)
+	}
+
 	if p.Semantics != nil && !p.Semantics.IsValid() {
 		validationErrors = append(validationErrors, p.Semantics.GetValidationErrors()...)
 	}
 
-	if p.RelevantDates != nil {
-		for _, prd := range p.RelevantDates {
-			if !prd.IsValid() {
-				validationErrors = append(validationErrors, prd.GetValidationErrors()...)
-			}
+	validationErrors = append(validationErrors, relevantDatesValidationErrors("Pass", p.RelevantDates)...)
+
+	validationErrors = append(validationErrors, p.styleSchemeValidationErrors()...)
+
+	identifiers := make(map[string]bool)
+	for _, u := range p.UpcomingPassInformation {
+		if !u.IsValid() {
+			validationErrors = append(validationErrors, u.GetValidationErrors()...)
 		}
+
+		if u.Identifier != "" && identifiers[u.Identifier] {
+			validationErrors = append(validationErrors, fmt.Sprintf("Pass: The upcoming pass identifier %q is used more than once", u.Identifier))
+		}
+		identifiers[u.Identifier] = true
 	}
 
 	if p.EventDetail != nil && !p.EventDetail.IsValid() {
@@ -301,6 +403,61 @@ func (p *Pass) GetValidationErrors() []string {
 	return validationErrors
 }
 
+// GetValidationWarnings returns the problems found in the pass that don't prevent it from being signed, but that
+// may make it look or behave differently than expected on some devices.
+func (p *Pass) GetValidationWarnings() []string {
+	var warnings []string
+
+	if p.RelevantDate != nil && len(p.RelevantDates) > 0 {
+		warnings = append(warnings, "Pass: The deprecated relevantDate is ignored by devices that support relevantDates")
+	}
+
+	if p.Semantics != nil && len(p.Semantics.RelevantDates) > 0 {
+		if p.RelevantDate != nil {
+			warnings = append(warnings, "Pass: The deprecated relevantDate is used along with the relevantDates semantic tag, use MigrateRelevantDates to only keep relevantDates")
+		}
+		if len(p.RelevantDates) > 0 {
+			warnings = append(warnings, "Pass: Both relevantDates and the relevantDates semantic tag are set, the semantic tag is only read by older devices")
+		}
+	}
+
+	if len(p.Barcodes) > 0 {
+		onlyCode128 := true
+		for _, b := range p.Barcodes {
+			if b.Format != BarcodeFormatCode128 {
+				onlyCode128 = false
+			}
+		}
+
+		if onlyCode128 {
+			warnings = append(warnings, "Pass: Code128 barcodes are not supported on watchOS, add a barcode with another format as a fallback")
+		}
+	}
+
+	warnings = append(warnings, p.styleSchemeValidationWarnings()...)
+
+	if p.Semantics != nil {
+		warnings = append(warnings, p.semanticStyleWarnings("Pass", p.Semantics)...)
+	}
+
+	if gp := p.genericPass(); gp != nil {
+		for _, fields := range [][]Field{gp.HeaderFields, gp.PrimaryFields, gp.SecondaryFields, gp.AuxiliaryFields, gp.BackFields, gp.AdditionalInfoFields} {
+			for _, f := range fields {
+				if f.Semantics != nil {
+					warnings = append(warnings, p.semanticStyleWarnings(fmt.Sprintf("Field %q", f.Key), f.Semantics)...)
+				}
+			}
+		}
+	}
+
+	return warnings
+}
+
+// GetValidationFindings returns both the validation errors and warnings of the pass.
+func (p *Pass) GetValidationFindings() ValidationFindings {
+	return newValidationFindings(p.GetValidationErrors(), p.GetValidationWarnings())
+}
+
 func NewGenericPass() *GenericPass {
 	return &GenericPass{}
 }
@@ -436,6 +593,7 @@ func (e *EventDetail) GetValidationErrors() []string {
 	if e.EventLocation != nil && !e.EventLocation.IsValid() {
 		validationErrors = append(validationErrors, e.EventLocation.GetValidationErrors()...)
 	}
+	validationErrors = append(validationErrors, validateURL("EventDetail", "eventWebsiteURL", e.EventWebsiteURL, "https", "http")...)
 	return validationErrors
 }
 
@@ -459,6 +617,7 @@ func (v *VenueDetail) GetValidationErrors() []string {
 	if v.VenueLocation != nil && !v.VenueLocation.IsValid() {
 		validationErrors = append(validationErrors, v.VenueLocation.GetValidationErrors()...)
 	}
+	validationErrors = append(validationErrors, validateURL("VenueDetail", "venueWebsiteURL", v.VenueWebsiteURL, "https", "http")...)
 	return validationErrors
 }
 
@@ -523,8 +682,12 @@ func (f *Field) GetValidationErrors() []string {
 		case float32:
 		case float64:
 		case time.Time:
+		case json.Number:
+			if !isDecimalNumber(f.Value.(json.Number)) {
+				validationErrors = append(validationErrors, "Field: Invalid number value")
+			}
 		default:
-			validationErrors = append(validationErrors, "Field: Invalid value type. Allowed: string, int, float, time.Time")
+			validationErrors = append(validationErrors, "Field: Invalid value type. Allowed: string, int, float, json.Number, time.Time")
 		}
 	}
 
@@ -536,6 +699,12 @@ func (f *Field) GetValidationErrors() []string {
 		validationErrors = append(validationErrors, "Field: Can't be number/currency and date at the same time")
 	}
 
+	if av, ok := f.AttributedValue.(string); ok {
+		if err := validateAttributedValue(av); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("Field: Invalid attributedValue. %v", err))
+		}
+	}
+
 	if strings.TrimSpace(f.ChangeMessage) != "" && !strings.Contains(f.ChangeMessage, "%@") {
 		validationErrors = append(validationErrors, "Field: ChangeMessage needs to contain %@ placeholder")
 	}
@@ -549,6 +718,7 @@ func (f *Field) GetValidationErrors() []string {
 		case int64:
 		case float32:
 		case float64:
+		case json.Number:
 		default:
 			validationErrors = append(validationErrors, "Field: When using currencies, the values have to be numbers")
 		}
@@ -582,6 +752,16 @@ func (b *Beacon) GetValidationErrors() []string {
 
 	if strings.TrimSpace(b.ProximityUUID) == "" {
 		validationErrors = append(validationErrors, "Beacon: Not all required Fields are set: proximityUUID")
+	} else if !uuidRegexp.MatchString(b.ProximityUUID) {
+		validationErrors = append(validationErrors, fmt.Sprintf("Beacon: The proximityUUID %q is not a valid UUID", b.ProximityUUID))
+	}
+
+	if b.Major < 0 || b.Major > math.MaxUint16 {
+		validationErrors = append(validationErrors, fmt.Sprintf("Beacon: The major value %d must be between 0 and %d", b.Major, math.MaxUint16))
+	}
+
+	if b.Minor < 0 || b.Minor > math.MaxUint16 {
+		validationErrors = append(validationErrors, fmt.Sprintf("Beacon: The minor value %d must be between 0 and %d", b.Minor, math.MaxUint16))
 	}
 
 	return validationErrors
@@ -600,7 +780,22 @@ func (l *Location) IsValid() bool {
 }
 
 func (l *Location) GetValidationErrors() []string {
-	return []string{}
+	return validateCoordinates("Location", l.Latitude, l.Longitude)
+}
+
+// SetNearestLocations replaces the Locations of the pass with the stores closest to reference, up to the
+// maximum of 10 locations a pass can have.
+func (p *Pass) SetNearestLocations(reference Location, stores []Location) {
+	sorted := slices.Clone(stores)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return distanceMeters(reference, sorted[i]) < distanceMeters(reference, sorted[j])
+	})
+
+	if len(sorted) > maxLocations {
+		sorted = sorted[:maxLocations]
+	}
+
+	p.Locations = sorted
 }
 
 // Barcode Representation of https://developer.apple.com/documentation/walletpasses/pass/barcodes
@@ -625,10 +820,17 @@ func (b *Barcode) GetValidationErrors() []string {
 	return validationErrors
 }
 
+// PWAssociatedApp is an Android app associated with the pass, in the format read by PassWallet and other Android
+// wallet apps. They show the app on the pass, and launch it with the AppLaunchURL of the pass.
 type PWAssociatedApp struct {
-	Title        string
-	IdGooglePlay string
-	IdAmazon     string
+	Title        string `json:"title,omitempty"`
+	IdGooglePlay string `json:"idGooglePlay,omitempty"`
+	IdAmazon     string `json:"idAmazon,omitempty"`
+}
+
+// AddAssociatedApp associates an Android app with the pass.
+func (p *Pass) AddAssociatedApp(app PWAssociatedApp) {
+	p.AssociatedApps = append(p.AssociatedApps, app)
 }
 
 func (a *PWAssociatedApp) IsValid() bool {
@@ -636,7 +838,21 @@ func (a *PWAssociatedApp) IsValid() bool {
 }
 
 func (a *PWAssociatedApp) GetValidationErrors() []string {
-	return []string{}
+	var validationErrors []string
+
+	if a.IdGooglePlay != "" && !googlePlayIDRegexp.MatchString(a.IdGooglePlay) {
+		validationErrors = append(validationErrors, fmt.Sprintf("PWAssociatedApp: The idGooglePlay %q is not a valid Android package name", a.IdGooglePlay))
+	}
+
+	if a.IdAmazon != "" && !amazonIDRegexp.MatchString(a.IdAmazon) {
+		validationErrors = append(validationErrors, fmt.Sprintf("PWAssociatedApp: The idAmazon %q is not a valid Amazon Standard Identification Number", a.IdAmazon))
+	}
+
+	if a.IdGooglePlay == "" && a.IdAmazon == "" {
+		validationErrors = append(validationErrors, "PWAssociatedApp: Either idGooglePlay or idAmazon is required")
+	}
+
+	return validationErrors
 }
 
 // NFC Representation of https://developer.apple.com/documentation/walletpasses/pass/nfc
@@ -646,6 +862,75 @@ type NFC struct {
 	RequiresAuthentication bool   `json:"requiresAuthentication,omitempty"`
 }
 
+// SetEncryptionPublicKey sets the EncryptionPublicKey from an *ecdsa.PublicKey or *ecdh.PublicKey on the P-256
+// curve, encoded as the Base64 X.509 SubjectPublicKeyInfo that Wallet expects.
+func (n *NFC) SetEncryptionPublicKey(key crypto.PublicKey) error {
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return fmt.Errorf("NFC: the encryption public key must use the P-256 curve")
+		}
+	case *ecdh.PublicKey:
+		if k.Curve() != ecdh.P256() {
+			return fmt.Errorf("NFC: the encryption public key must use the P-256 curve")
+		}
+	default:
+		return fmt.Errorf("NFC: unsupported encryption public key type %T", key)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return err
+	}
+
+	n.EncryptionPublicKey = base64.StdEncoding.EncodeToString(der)
+	return nil
+}
+
+func (n *NFC) IsValid() bool {
+	return len(n.GetValidationErrors()) == 0
+}
+
+func (n *NFC) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if strings.TrimSpace(n.Message) == "" {
+		validationErrors = append(validationErrors, "NFC: Not all required Fields are set: message")
+	}
+
+	if len(n.Message) > maxNFCMessageBytes {
+		validationErrors = append(validationErrors, fmt.Sprintf("NFC: The message is %d bytes long, the maximum is %d", len(n.Message), maxNFCMessageBytes))
+	}
+
+	if n.EncryptionPublicKey != "" {
+		if err := validateNFCEncryptionPublicKey(n.EncryptionPublicKey); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("NFC: Invalid encryptionPublicKey. %v", err))
+		}
+	}
+
+	return validationErrors
+}
+
+// validateNFCEncryptionPublicKey checks that key is a Base64 X.509 SubjectPublicKeyInfo for an ECDH P-256 key.
+func validateNFCEncryptionPublicKey(key string) error {
+	der, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("not Base64 encoded: %w", err)
+	}
+
+	pub, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return fmt.Errorf("not an X.509 SubjectPublicKeyInfo: %w", err)
+	}
+
+	ec, ok := pub.(*ecdsa.PublicKey)
+	if !ok || ec.Curve != elliptic.P256() {
+		return fmt.Errorf("not an elliptic curve P-256 key")
+	}
+
+	return nil
+}
+
 // Personalization Representation of https://developer.apple.com/documentation/walletpasses/personalize
 type Personalization struct {
 	RequiredPersonalizationFields []PassPersonalizationField `json:"requiredPersonalizationFields"`
@@ -661,6 +946,11 @@ func (pz *Personalization) IsValid() bool {
 	return len(pz.GetValidationErrors()) == 0
 }
 
+// GetValidationFindings returns the validation errors of the personalization dictionary. It has no warnings.
+func (pz *Personalization) GetValidationFindings() ValidationFindings {
+	return newValidationFindings(pz.GetValidationErrors(), nil)
+}
+
 func (pz *Personalization) GetValidationErrors() []string {
 	var validationErrors []string
 
@@ -685,17 +975,61 @@ func (prd PassRelevantDate) toJSON() ([]byte, error) {
 	return json.Marshal(prd)
 }
 
+// UpcomingPass Representation of https://developer.apple.com/documentation/walletpasses/upcomingpassinformationentry
 type UpcomingPass struct {
 	Identifier      string                       `json:"identifier,omitempty"`
 	Name            string                       `json:"name,omitempty"`
-	Type            string                       `json:"type,omitempty"`
+	Type            UpcomingPassType             `json:"type,omitempty"`
 	DateInformation *UpcomingPassDateInformation `json:"dateInformation,omitempty"`
 }
+
+func (u *UpcomingPass) IsValid() bool {
+	return len(u.GetValidationErrors()) == 0
+}
+
+func (u *UpcomingPass) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if strings.TrimSpace(u.Identifier) == "" || strings.TrimSpace(u.Name) == "" || string(u.Type) == "" {
+		validationErrors = append(validationErrors, fmt.Sprintf("UpcomingPass: Not all required Fields are set. Identifier: %q, Name: %q, Type: %q", u.Identifier, u.Name, u.Type))
+	}
+
+	if string(u.Type) != "" && u.Type != UpcomingPassTypeEvent {
+		validationErrors = append(validationErrors, fmt.Sprintf("UpcomingPass: Unknown type %q", u.Type))
+	}
+
+	if u.DateInformation != nil && !u.DateInformation.IsValid() {
+		validationErrors = append(validationErrors, u.DateInformation.GetValidationErrors()...)
+	}
+
+	return validationErrors
+}
+
 type UpcomingPassDateInformation struct {
 	Date     *time.Time `json:"date,omitempty"`
 	TimeZone string     `json:"timeZone,omitempty"`
 }
 
+func (u *UpcomingPassDateInformation) IsValid() bool {
+	return len(u.GetValidationErrors()) == 0
+}
+
+func (u *UpcomingPassDateInformation) GetValidationErrors() []string {
+	var validationErrors []string
+
+	if u.Date == nil {
+		validationErrors = append(validationErrors, "UpcomingPassDateInformation: Not all required Fields are set: date")
+	}
+
+	if u.TimeZone != "" {
+		if _, err := time.LoadLocation(u.TimeZone); err != nil {
+			validationErrors = append(validationErrors, fmt.Sprintf("UpcomingPassDateInformation: The timeZone %q is not a valid time zone identifier", u.TimeZone))
+		}
+	}
+
+	return validationErrors
+}
+
 func (prd *PassRelevantDate) IsValid() bool {
 	return len(prd.GetValidationErrors()) == 0
 }
@@ -725,6 +1059,10 @@ func (prd *PassRelevantDate) GetValidationErrors() []string {
 		}
 	}
 
+	if prd.StartDate != nil && prd.EndDate != nil && !prd.StartDate.Before(*prd.EndDate) {
+		validationErrors = append(validationErrors, "PassRelevantDate: StartDate must be before EndDate")
+	}
+
 	if prd.Date == nil && prd.StartDate == nil && prd.EndDate == nil {
 		validationErrors = append(validationErrors, "PassRelevantDate: Either Date alone, or StartDate and EndDate be defined.")
 	}
@@ -1,6 +1,14 @@
 package passkit
 
 import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -216,7 +224,7 @@ func getBasicBeacon() Beacon {
 	return Beacon{
 		Major:         3,
 		Minor:         29,
-		ProximityUUID: "123456789-abcdefghijklmnopqrstuwxyz",
+		ProximityUUID: "E2C56DB5-DFFB-48D2-B060-D0F5A71096E0",
 		RelevantText:  "County of Zadar",
 	}
 }
@@ -649,7 +657,7 @@ func getBasicPass() Pass {
 		ExpirationDate:             &exp,
 		Barcodes:                   []Barcode{getBasicBarcode()},
 		SerialNumber:               "1234",
-		PassTypeIdentifier:         "test",
+		PassTypeIdentifier:         "pass.test",
 		TeamIdentifier:             "TEAM1",
 		AuthenticationToken:        "asldadilno21o31n41lkasndio123",
 		Generic:                    &GenericPass{PrimaryFields: []Field{f}},
@@ -782,6 +790,7 @@ func TestPass_NoPass(t *testing.T) {
 
 func TestPass_InvalidAuthToken(t *testing.T) {
 	pass := getBasicPass()
+	pass.WebServiceURL = "https://example.com/passes"
 	pass.AuthenticationToken = ""
 
 	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
@@ -794,6 +803,15 @@ func TestPass_InvalidAuthToken(t *testing.T) {
 	}
 }
 
+func TestPass_NoWebServiceAuthToken(t *testing.T) {
+	pass := getBasicPass()
+	pass.AuthenticationToken = ""
+
+	if !pass.IsValid() {
+		t.Errorf("Pass without web service should not need an authentication token. Reason: %v", pass.GetValidationErrors())
+	}
+}
+
 func TestPass_MultiplePass(t *testing.T) {
 	pass := getBasicPass()
 	f := getBasicField()
@@ -1048,12 +1066,78 @@ func TestBoardingPass_NoTransitType(t *testing.T) {
 func TestPWAssociatedApp_GetSet(t *testing.T) {
 	pw := PWAssociatedApp{}
 
+	if pw.IsValid() {
+		t.Errorf("PWAssociatedApp without IDs should be invalid")
+	}
+
+	if len(pw.GetValidationErrors()) != 1 {
+		t.Errorf("PWAssociatedApp should have one error. Have: %v", len(pw.GetValidationErrors()))
+	}
+
+	pw.IdGooglePlay = "com.example.app"
 	if !pw.IsValid() {
 		t.Errorf("PWAssociatedApp should be valid. Reason: %v", pw.GetValidationErrors())
 	}
+}
 
-	if len(pw.GetValidationErrors()) != 0 {
-		t.Errorf("PWAssociatedApp should have no errors. Have: %v", len(pw.GetValidationErrors()))
+func TestPWAssociatedApp_IDs(t *testing.T) {
+	valid := []PWAssociatedApp{
+		{Title: "Example", IdGooglePlay: "com.example.app"},
+		{Title: "Example", IdAmazon: "B004SBT8TY"},
+		{IdGooglePlay: "com.example.app_2", IdAmazon: "B004SBT8TY"},
+	}
+	for _, a := range valid {
+		if !a.IsValid() {
+			t.Errorf("PWAssociatedApp %+v should be valid. Reason: %v", a, a.GetValidationErrors())
+		}
+	}
+
+	invalid := []PWAssociatedApp{
+		{IdGooglePlay: "example"},
+		{IdGooglePlay: "com.example.1app"},
+		{IdAmazon: "b004sbt8ty"},
+		{IdAmazon: "B004"},
+		{Title: "No IDs"},
+	}
+	for _, a := range invalid {
+		if len(a.GetValidationErrors()) != 1 {
+			t.Errorf("PWAssociatedApp %+v should have one error. Have: %v", a, a.GetValidationErrors())
+		}
+	}
+}
+
+func TestPass_AssociatedApps(t *testing.T) {
+	pass := getBasicPass()
+	pass.AddAssociatedApp(PWAssociatedApp{Title: "Example", IdGooglePlay: "com.example.app"})
+
+	b, err := json.Marshal(pass)
+	if err != nil {
+		t.Fatalf("could not marshal pass. %v", err)
+	}
+
+	if !strings.Contains(string(b), `"associatedApps":[{"title":"Example","idGooglePlay":"com.example.app"}]`) {
+		t.Errorf("Associated apps should be serialized. %s", b)
+	}
+
+	if !pass.IsValid() {
+		t.Errorf("Pass should be valid. Reason: %v", pass.GetValidationErrors())
+	}
+
+	pass.AddAssociatedApp(PWAssociatedApp{IdGooglePlay: "not a package"})
+	if pass.IsValid() {
+		t.Errorf("Pass with an invalid associated app should be invalid")
+	}
+
+	empty := getBasicPass()
+	empty.AddAssociatedApp(PWAssociatedApp{})
+	if empty.IsValid() {
+		t.Errorf("Pass with an empty associated app should be invalid")
+	}
+
+	c := pass.Clone()
+	c.AssociatedApps[0].Title = "Changed"
+	if pass.AssociatedApps[0].Title != "Example" {
+		t.Errorf("Clone should copy the associated apps")
 	}
 }
 
@@ -1082,3 +1166,279 @@ func TestPassRelevantDate_GetSet(t *testing.T) {
 		t.Errorf("PassRelevantDate should be valid. Reason: %v", pdr.GetValidationErrors())
 	}
 }
+
+func TestPass_InvalidColors(t *testing.T) {
+	pass := getBasicPass()
+	pass.ForegroundColor = "rgb(0,0,0)"
+	pass.BackgroundColor = "rgb( 23, 187 , 82 )"
+
+	if !pass.IsValid() {
+		t.Errorf("Pass should be valid. Reason: %v", pass.GetValidationErrors())
+	}
+
+	pass.ForegroundColor = "#ffffff"
+	pass.LabelColor = "rgb(256,0,0)"
+	pass.FooterBackgroundColor = "rgb(0,0)"
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass should be invalid")
+	}
+
+	if len(pass.GetValidationErrors()) != 3 {
+		t.Errorf("Pass should have three errors. Have: %v", len(pass.GetValidationErrors()))
+	}
+}
+
+func TestPass_InvalidPassTypeIdentifier(t *testing.T) {
+	pass := getBasicPass()
+	pass.PassTypeIdentifier = "com.example.pass"
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass should be invalid")
+	}
+
+	if len(pass.GetValidationErrors()) != 1 {
+		t.Errorf("Pass should have one error. Have: %v", len(pass.GetValidationErrors()))
+	}
+}
+
+func TestPass_HTTPWebService(t *testing.T) {
+	pass := getBasicPass()
+	pass.WebServiceURL = "http://192.168.1.10:8080/passes"
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass with an HTTP web service should be invalid")
+	}
+
+	pass.SetAllowHTTPWebService(true)
+	if !pass.IsValid() {
+		t.Errorf("Pass should be valid when HTTP is allowed. Reason: %v", pass.GetValidationErrors())
+	}
+
+	pass.WebServiceURL = "ftp://example.com"
+	if pass.IsValid() {
+		t.Errorf("Pass with an FTP web service should be invalid")
+	}
+}
+
+func TestPass_InvalidURLs(t *testing.T) {
+	pass := getBasicPass()
+	pass.AppLaunchURL = "not a url"
+	pass.BagPolicyURL = "example.com/bags"
+	pass.OrderFoodURL = "mailto:food@example.com"
+	pass.EventDetail = &EventDetail{EventWebsiteURL: "https://"}
+	pass.VenueDetail = &VenueDetail{VenueWebsiteURL: "https://example.com/venue"}
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass should be invalid")
+	}
+
+	if len(pass.GetValidationErrors()) != 4 {
+		t.Errorf("Pass should have four errors. Have: %v", len(pass.GetValidationErrors()))
+	}
+}
+
+func TestNFC_Valid(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	nfc := NFC{Message: "loyalty-1234"}
+	if err := nfc.SetEncryptionPublicKey(&key.PublicKey); err != nil {
+		t.Fatalf("could not set encryption key. %v", err)
+	}
+
+	if !nfc.IsValid() {
+		t.Errorf("NFC should be valid. Reason: %v", nfc.GetValidationErrors())
+	}
+
+	ecdhKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	if err := nfc.SetEncryptionPublicKey(ecdhKey.PublicKey()); err != nil {
+		t.Fatalf("could not set encryption key. %v", err)
+	}
+
+	if !nfc.IsValid() {
+		t.Errorf("NFC should be valid. Reason: %v", nfc.GetValidationErrors())
+	}
+}
+
+func TestNFC_InvalidKeys(t *testing.T) {
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	nfc := NFC{Message: "loyalty-1234"}
+	if err := nfc.SetEncryptionPublicKey(&p384.PublicKey); err == nil {
+		t.Errorf("P-384 key should be rejected")
+	}
+
+	x25519, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	if err := nfc.SetEncryptionPublicKey(x25519.PublicKey()); err == nil {
+		t.Errorf("X25519 key should be rejected")
+	}
+
+	for _, key := range []string{"not base64!", base64.StdEncoding.EncodeToString([]byte("not a key"))} {
+		nfc.EncryptionPublicKey = key
+		if nfc.IsValid() {
+			t.Errorf("Encryption key %q should be invalid", key)
+		}
+	}
+}
+
+func TestNFC_MessageTooLong(t *testing.T) {
+	pass := getBasicPass()
+	pass.Nfc = &NFC{Message: strings.Repeat("a", 65)}
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass should be invalid")
+	}
+
+	if len(pass.GetValidationErrors()) != 1 {
+		t.Errorf("Pass should have one error. Have: %v", len(pass.GetValidationErrors()))
+	}
+
+	pass.Nfc.Message = strings.Repeat("a", 64)
+	if !pass.IsValid() {
+		t.Errorf("Pass should be valid. Reason: %v", pass.GetValidationErrors())
+	}
+}
+
+func TestBeacon_InvalidValues(t *testing.T) {
+	bea := getBasicBeacon()
+	bea.ProximityUUID = "123456789-abcdefghijklmnopqrstuwxyz"
+	bea.Major = 65536
+	bea.Minor = -1
+
+	t.Logf("%d, %v", len(bea.GetValidationErrors()), bea.GetValidationErrors())
+	if bea.IsValid() {
+		t.Errorf("Beacon should be invalid")
+	}
+
+	if len(bea.GetValidationErrors()) != 3 {
+		t.Errorf("Beacon should have three errors. Have: %v", len(bea.GetValidationErrors()))
+	}
+}
+
+func TestLocation_InvalidCoordinates(t *testing.T) {
+	location := getBasicLocation()
+	location.Latitude = 90.5
+	location.Longitude = -180.1
+
+	t.Logf("%d, %v", len(location.GetValidationErrors()), location.GetValidationErrors())
+	if location.IsValid() {
+		t.Errorf("Location should be invalid")
+	}
+
+	if len(location.GetValidationErrors()) != 2 {
+		t.Errorf("Location should have two errors. Have: %v", len(location.GetValidationErrors()))
+	}
+}
+
+func TestPass_TooManyLocationsAndBeacons(t *testing.T) {
+	pass := getBasicPass()
+	for i := 0; i < 11; i++ {
+		pass.Locations = append(pass.Locations, getBasicLocation())
+		pass.Beacons = append(pass.Beacons, getBasicBeacon())
+	}
+	pass.MaxDistance = -1
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if pass.IsValid() {
+		t.Errorf("Pass should be invalid")
+	}
+
+	if len(pass.GetValidationErrors()) != 3 {
+		t.Errorf("Pass should have three errors. Have: %v", len(pass.GetValidationErrors()))
+	}
+}
+
+func TestPass_InvalidLocation(t *testing.T) {
+	pass := getBasicPass()
+	pass.Locations = []Location{{Latitude: 100, Longitude: 0}}
+	pass.Semantics = &SemanticTag{VenueLocation: &SemanticTagLocation{Latitude: 0, Longitude: 200}}
+
+	t.Logf("%d, %v", len(pass.GetValidationErrors()), pass.GetValidationErrors())
+	if len(pass.GetValidationErrors()) != 2 {
+		t.Errorf("Pass should have two errors. Have: %v", len(pass.GetValidationErrors()))
+	}
+}
+
+func TestPass_SetNearestLocations(t *testing.T) {
+	madrid := Location{Latitude: 40.4168, Longitude: -3.7038}
+
+	var stores []Location
+	// Stores further away from Madrid the higher the index
+	for i := 14; i >= 0; i-- {
+		stores = append(stores, Location{Latitude: 40.4168 + float64(i)*0.1, Longitude: -3.7038, RelevantText: strconv.Itoa(i)})
+	}
+
+	pass := getBasicPass()
+	pass.SetNearestLocations(madrid, stores)
+
+	if len(pass.Locations) != 10 {
+		t.Fatalf("Pass should have ten locations. Have: %v", len(pass.Locations))
+	}
+
+	for i, l := range pass.Locations {
+		if l.RelevantText != strconv.Itoa(i) {
+			t.Errorf("Locations should be sorted by distance. Have %v at position %d", l.RelevantText, i)
+		}
+	}
+
+	if len(stores) != 15 || stores[0].RelevantText != "14" {
+		t.Errorf("The stores should not be modified")
+	}
+}
+
+func TestPass_ValidationFindings(t *testing.T) {
+	pass := getBasicPass()
+	now := time.Now()
+	prd := getBasicRelevantDate()
+	pass.RelevantDate = &now
+	pass.RelevantDates = []PassRelevantDate{prd}
+	pass.SerialNumber = ""
+
+	findings := pass.GetValidationFindings()
+	t.Logf("%d, %v", len(findings), findings)
+
+	if !findings.HasErrors() || len(findings.Errors()) != 1 {
+		t.Errorf("Pass should have one error. Have: %v", findings.Errors())
+	}
+
+	if len(findings.Warnings()) != 1 {
+		t.Errorf("Pass should have one warning. Have: %v", findings.Warnings())
+	}
+
+	if len(pass.GetValidationErrors()) != 1 {
+		t.Errorf("Warnings should not be returned as errors. Have: %v", pass.GetValidationErrors())
+	}
+}
+
+func TestPass_Code128Warning(t *testing.T) {
+	pass := getBasicPass()
+	pass.Barcodes = []Barcode{{Format: BarcodeFormatCode128, Message: "1234", MessageEncoding: "utf-8"}}
+
+	if len(pass.GetValidationWarnings()) != 1 {
+		t.Errorf("Pass should have one warning. Have: %v", pass.GetValidationWarnings())
+	}
+
+	pass.Barcodes = append(pass.Barcodes, getBasicBarcode())
+	if len(pass.GetValidationWarnings()) != 0 {
+		t.Errorf("Pass with a fallback barcode should have no warnings. Have: %v", pass.GetValidationWarnings())
+	}
+}
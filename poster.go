@@ -0,0 +1,98 @@
+package passkit
+
+import (
+	"fmt"
+	"slices"
+)
+
+// posterEventTicketSemantics are the semantic tags, by JSON name, that a poster event ticket needs to be displayed.
+// Either of the names in each group is enough.
+var posterEventTicketSemantics = [][]string{
+	{"eventName"},
+	{"venueName"},
+	{"eventStartDate", "eventStartDateInfo"},
+}
+
+// UsePosterEventTicket prefers the iOS 18 poster event ticket layout, falling back to the classic event ticket on
+// devices that don't support it. The pass must be an event ticket with an artwork image.
+func (p *Pass) UsePosterEventTicket() {
+	p.PreferredStyleSchemes = []StyleScheme{StyleSchemePosterEventTicket, StyleSchemeEventTicket}
+}
+
+// prefersPosterEventTicket reports whether the pass asks for the poster event ticket layout.
+func (p *Pass) prefersPosterEventTicket() bool {
+	return slices.Contains(p.PreferredStyleSchemes, StyleSchemePosterEventTicket)
+}
+
+func (p *Pass) styleSchemeValidationErrors() []string {
+	var validationErrors []string
+
+	for _, s := range p.PreferredStyleSchemes {
+		if s != StyleSchemePosterEventTicket && s != StyleSchemeEventTicket {
+			validationErrors = append(validationErrors, fmt.Sprintf("Pass: Unknown preferred style scheme %q", s))
+		}
+	}
+
+	if len(p.PreferredStyleSchemes) > 0 && p.EventTicket == nil {
+		validationErrors = append(validationErrors, "Pass: The preferredStyleSchemes are only allowed for event tickets")
+	}
+
+	if !p.prefersPosterEventTicket() {
+		return validationErrors
+	}
+
+	props, err := jsonProperties(p.Semantics)
+	if err != nil {
+		return append(validationErrors, fmt.Sprintf("Pass: Could not read the semantic tags. %v", err))
+	}
+
+	for _, group := range posterEventTicketSemantics {
+		found := false
+		for _, tag := range group {
+			if _, ok := props[tag]; ok {
+				found = true
+			}
+		}
+
+		if !found {
+			validationErrors = append(validationErrors, fmt.Sprintf("Pass: Poster event tickets require the semantic tag %q", group[0]))
+		}
+	}
+
+	return validationErrors
+}
+
+// styleSchemeValidationWarnings checks that a poster event ticket still works on devices that only support the
+// classic event ticket layout.
+func (p *Pass) styleSchemeValidationWarnings() []string {
+	if !p.prefersPosterEventTicket() || p.EventTicket == nil {
+		return nil
+	}
+
+	var warnings []string
+
+	if !slices.Contains(p.PreferredStyleSchemes, StyleSchemeEventTicket) {
+		warnings = append(warnings, "Pass: Add the eventTicket style scheme after posterEventTicket so devices before iOS 18 display the classic event ticket")
+	}
+
+	if p.EventTicket.GenericPass == nil || len(p.EventTicket.PrimaryFields) == 0 {
+		warnings = append(warnings, "Pass: Poster event tickets should have primary fields, which devices before iOS 18 display instead of the poster")
+	}
+
+	return warnings
+}
+
+// posterArtworkErrors checks that a poster event ticket bundle has the artwork image.
+func posterArtworkErrors(p *Pass, files map[string][]byte) []string {
+	if !p.prefersPosterEventTicket() {
+		return nil
+	}
+
+	for _, name := range []string{BundleArtwork, BundleArtworkRetina, BundleArtworkRetinaHD} {
+		if _, ok := files[name]; ok {
+			return nil
+		}
+	}
+
+	return []string{"Template: Poster event tickets require an artwork image"}
+}
@@ -0,0 +1,113 @@
+package passkit
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func getBasicPosterPass() Pass {
+	start := time.Date(2026, 6, 1, 20, 0, 0, 0, time.UTC)
+	p := getBasicPass()
+	p.Generic = nil
+	p.EventTicket = NewEventTicket()
+	p.EventTicket.AddPrimaryFields(Field{Key: "event", Value: "The Concert"})
+	p.Semantics = &SemanticTag{EventName: "The Concert", VenueName: "The Venue", EventStartDate: &start}
+	p.UsePosterEventTicket()
+	return p
+}
+
+func TestPass_PosterEventTicket(t *testing.T) {
+	p := getBasicPosterPass()
+	if !p.IsValid() {
+		t.Errorf("Poster event ticket should be valid. %v", p.GetValidationErrors())
+	}
+
+	if w := p.styleSchemeValidationWarnings(); len(w) != 0 {
+		t.Errorf("Poster event ticket with fallback should not have warnings. %v", w)
+	}
+}
+
+func TestPass_PosterEventTicketMissingSemantics(t *testing.T) {
+	p := getBasicPosterPass()
+	p.Semantics.VenueName = ""
+	p.Semantics.EventStartDate = nil
+
+	errs := p.GetValidationErrors()
+	if len(errs) != 2 {
+		t.Errorf("Poster event ticket without venue and start date should have 2 errors, got %v", errs)
+	}
+
+	p = getBasicPosterPass()
+	p.Semantics.EventStartDate = nil
+	p.Semantics.EventStartDateInfo = &SemanticTagEventDateInfo{Date: &time.Time{}}
+	if !p.IsValid() {
+		t.Errorf("eventStartDateInfo should be accepted instead of eventStartDate. %v", p.GetValidationErrors())
+	}
+}
+
+func TestPass_StyleSchemesOnlyForEventTickets(t *testing.T) {
+	p := getBasicPass()
+	p.PreferredStyleSchemes = []StyleScheme{StyleSchemeEventTicket}
+	if p.IsValid() {
+		t.Errorf("Style schemes on a generic pass should be invalid")
+	}
+
+	p = getBasicPosterPass()
+	p.PreferredStyleSchemes = append(p.PreferredStyleSchemes, "boardingPass")
+	if p.IsValid() {
+		t.Errorf("Unknown style schemes should be invalid")
+	}
+}
+
+func TestPass_PosterEventTicketFallbackWarnings(t *testing.T) {
+	p := getBasicPosterPass()
+	p.PreferredStyleSchemes = []StyleScheme{StyleSchemePosterEventTicket}
+	p.EventTicket.PrimaryFields = nil
+
+	if w := p.GetValidationWarnings(); len(w) != 2 {
+		t.Errorf("Poster event ticket without fallback should have 2 warnings, got %v", w)
+	}
+}
+
+func TestValidatePassBundle_PosterArtwork(t *testing.T) {
+	p := getBasicPosterPass()
+	files := map[string][]byte{BundleIcon: {1}}
+
+	findings := validatePassBundleFiles(&p, nil, files)
+	if !findings.HasErrors() || !strings.Contains(strings.Join(findings.Errors(), " "), "artwork") {
+		t.Errorf("Poster event ticket without artwork should be invalid. %v", findings)
+	}
+
+	files[BundleArtworkRetina] = []byte{1}
+	if findings := validatePassBundleFiles(&p, nil, files); findings.HasErrors() {
+		t.Errorf("Poster event ticket with artwork should be valid. %v", findings.Errors())
+	}
+}
+
+func TestUpcomingPass_Validation(t *testing.T) {
+	now := time.Now()
+	u := UpcomingPass{
+		Identifier:      "1",
+		Name:            "Day 2",
+		Type:            UpcomingPassTypeEvent,
+		DateInformation: &UpcomingPassDateInformation{Date: &now, TimeZone: "Europe/Madrid"},
+	}
+	if !u.IsValid() {
+		t.Errorf("Upcoming pass should be valid. %v", u.GetValidationErrors())
+	}
+
+	u.Type = "concert"
+	u.DateInformation.TimeZone = "Mars/Olympus_Mons"
+	if len(u.GetValidationErrors()) != 2 {
+		t.Errorf("Upcoming pass with unknown type and time zone should have 2 errors, got %v", u.GetValidationErrors())
+	}
+
+	p := getBasicPosterPass()
+	u.Type = UpcomingPassTypeEvent
+	u.DateInformation.TimeZone = ""
+	p.UpcomingPassInformation = []UpcomingPass{u, u}
+	if p.IsValid() {
+		t.Errorf("Upcoming passes with the same identifier should be invalid")
+	}
+}
@@ -0,0 +1,158 @@
+package passkit
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Registration is a device registered for updates of a pass.
+type Registration struct {
+	DeviceLibraryIdentifier string
+	PushToken               string
+}
+
+// RegistrationStore keeps the devices registered for pass updates, their push tokens, and the update tags of the
+// passes, for the web service.
+type RegistrationStore interface {
+	// Register registers the device for updates of the pass, storing its push token, and reports whether the device
+	// wasn't registered for the pass already.
+	Register(ctx context.Context, deviceLibraryIdentifier, pushToken, passTypeIdentifier, serialNumber string) (bool, error)
+	// Unregister stops sending updates of the pass to the device. Devices without passes are removed.
+	Unregister(ctx context.Context, deviceLibraryIdentifier, passTypeIdentifier, serialNumber string) error
+	// Registrations returns the devices registered for updates of the pass.
+	Registrations(ctx context.Context, passTypeIdentifier, serialNumber string) ([]Registration, error)
+	// UpdatedSerials returns the serial numbers of the passes of the type registered by the device that were
+	// updated after the passesUpdatedSince tag, and the tag to use in the next request. If the tag is empty every
+	// pass of the device is returned.
+	UpdatedSerials(ctx context.Context, deviceLibraryIdentifier, passTypeIdentifier, passesUpdatedSince string) ([]string, string, error)
+	// PassUpdated gives the pass a new update tag, so it is returned by UpdatedSerials.
+	PassUpdated(ctx context.Context, passTypeIdentifier, serialNumber string) error
+}
+
+// parseUpdateTag returns the update time in a tag. Tags are Unix nanoseconds, so they sort like the updates.
+func parseUpdateTag(tag string) (int64, bool) {
+	if tag == "" {
+		return 0, false
+	}
+
+	since, err := strconv.ParseInt(tag, 10, 64)
+	return since, err == nil
+}
+
+// newUpdateTag returns a tag for an update made now, greater than the previous tag of the pass.
+func newUpdateTag(previous int64) int64 {
+	return max(time.Now().UnixNano(), previous+1)
+}
+
+type passKey struct {
+	passTypeIdentifier string
+	serialNumber       string
+}
+
+// MemoryRegistrationStore is a RegistrationStore that keeps the registrations in memory, for tests and development.
+type MemoryRegistrationStore struct {
+	mu            sync.Mutex
+	pushTokens    map[string]string
+	registrations map[passKey]map[string]bool
+	updated       map[passKey]int64
+}
+
+func NewMemoryRegistrationStore() *MemoryRegistrationStore {
+	return &MemoryRegistrationStore{
+		pushTokens:    make(map[string]string),
+		registrations: make(map[passKey]map[string]bool),
+		updated:       make(map[passKey]int64),
+	}
+}
+
+func (m *MemoryRegistrationStore) Register(_ context.Context, deviceLibraryIdentifier, pushToken, passTypeIdentifier, serialNumber string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pushTokens[deviceLibraryIdentifier] = pushToken
+
+	key := passKey{passTypeIdentifier, serialNumber}
+	if m.registrations[key] == nil {
+		m.registrations[key] = make(map[string]bool)
+	}
+
+	if m.registrations[key][deviceLibraryIdentifier] {
+		return false, nil
+	}
+
+	m.registrations[key][deviceLibraryIdentifier] = true
+	return true, nil
+}
+
+func (m *MemoryRegistrationStore) Unregister(_ context.Context, deviceLibraryIdentifier, passTypeIdentifier, serialNumber string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := passKey{passTypeIdentifier, serialNumber}
+	delete(m.registrations[key], deviceLibraryIdentifier)
+	if len(m.registrations[key]) == 0 {
+		delete(m.registrations, key)
+	}
+
+	for _, devices := range m.registrations {
+		if devices[deviceLibraryIdentifier] {
+			return nil
+		}
+	}
+
+	delete(m.pushTokens, deviceLibraryIdentifier)
+	return nil
+}
+
+func (m *MemoryRegistrationStore) Registrations(_ context.Context, passTypeIdentifier, serialNumber string) ([]Registration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var registrations []Registration
+	for device := range m.registrations[passKey{passTypeIdentifier, serialNumber}] {
+		registrations = append(registrations, Registration{DeviceLibraryIdentifier: device, PushToken: m.pushTokens[device]})
+	}
+
+	sort.Slice(registrations, func(i, j int) bool {
+		return registrations[i].DeviceLibraryIdentifier < registrations[j].DeviceLibraryIdentifier
+	})
+	return registrations, nil
+}
+
+func (m *MemoryRegistrationStore) UpdatedSerials(_ context.Context, deviceLibraryIdentifier, passTypeIdentifier, passesUpdatedSince string) ([]string, string, error) {
+	since, hasTag := parseUpdateTag(passesUpdatedSince)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var serials []string
+	last := since
+	for key, devices := range m.registrations {
+		if key.passTypeIdentifier != passTypeIdentifier || !devices[deviceLibraryIdentifier] {
+			continue
+		}
+
+		updated := m.updated[key]
+		if hasTag && updated <= since {
+			continue
+		}
+
+		serials = append(serials, key.serialNumber)
+		last = max(last, updated)
+	}
+
+	sort.Strings(serials)
+	return serials, strconv.FormatInt(last, 10), nil
+}
+
+func (m *MemoryRegistrationStore) PassUpdated(_ context.Context, passTypeIdentifier, serialNumber string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := passKey{passTypeIdentifier, serialNumber}
+	m.updated[key] = newUpdateTag(m.updated[key])
+	return nil
+}
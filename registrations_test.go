@@ -0,0 +1,169 @@
+package passkit
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestMemoryRegistrationStore(t *testing.T) {
+	testRegistrationStore(t, NewMemoryRegistrationStore())
+}
+
+func TestSQLRegistrationStore(t *testing.T) {
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "registrations.db"))
+	if err != nil {
+		t.Fatalf("could not open database. %v", err)
+	}
+	defer db.Close()
+
+	s := NewSQLRegistrationStore(db)
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatalf("could not migrate. %v", err)
+	}
+
+	// Migrating twice must be harmless
+	if err := s.Migrate(context.Background()); err != nil {
+		t.Fatalf("could not migrate again. %v", err)
+	}
+
+	testRegistrationStore(t, s)
+}
+
+func TestSQLRegistrationStore_DollarPlaceholders(t *testing.T) {
+	s := NewSQLRegistrationStore(nil, WithDollarPlaceholders())
+	q := s.query("SELECT 1 WHERE a = ? AND b = ?")
+	if q != "SELECT 1 WHERE a = $1 AND b = $2" {
+		t.Errorf("unexpected query %q", q)
+	}
+}
+
+func TestWebService_RegistrationStore(t *testing.T) {
+	store := NewMemoryRegistrationStore()
+	s := newTestWebService(WithRegistrationStore(store))
+
+	w := doWebServiceRequest(s, "POST", "/v1/devices/device1/registrations/pass.test/1", "token1234567890ab", `{"pushToken":"push1"}`)
+	if w.Code != 201 {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+
+	registrations, err := store.Registrations(context.Background(), "pass.test", "1")
+	if err != nil {
+		t.Fatalf("could not list registrations. %v", err)
+	}
+
+	if !reflect.DeepEqual(registrations, []Registration{{DeviceLibraryIdentifier: "device1", PushToken: "push1"}}) {
+		t.Errorf("registration not stored, got %v", registrations)
+	}
+}
+
+// testRegistrationStore checks the behavior every RegistrationStore must have.
+func testRegistrationStore(t *testing.T, s RegistrationStore) {
+	ctx := context.Background()
+
+	created, err := s.Register(ctx, "device1", "push1", "pass.test", "1")
+	if err != nil || !created {
+		t.Fatalf("first registration should be created, got %v, %v", created, err)
+	}
+
+	created, err = s.Register(ctx, "device1", "push1b", "pass.test", "1")
+	if err != nil || created {
+		t.Errorf("repeated registration should not be created, got %v, %v", created, err)
+	}
+
+	for _, r := range [][2]string{{"device1", "2"}, {"device2", "1"}} {
+		if _, err := s.Register(ctx, r[0], "push-"+r[0], "pass.test", r[1]); err != nil {
+			t.Fatalf("could not register. %v", err)
+		}
+	}
+	if _, err := s.Register(ctx, "device1", "push-device1", "pass.other", "1"); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	registrations, err := s.Registrations(ctx, "pass.test", "1")
+	if err != nil {
+		t.Fatalf("could not list registrations. %v", err)
+	}
+
+	expected := []Registration{
+		{DeviceLibraryIdentifier: "device1", PushToken: "push-device1"},
+		{DeviceLibraryIdentifier: "device2", PushToken: "push-device2"},
+	}
+	if !reflect.DeepEqual(registrations, expected) {
+		t.Errorf("expected registrations %v, got %v", expected, registrations)
+	}
+
+	serials, tag, err := s.UpdatedSerials(ctx, "device1", "pass.test", "")
+	if err != nil {
+		t.Fatalf("could not list updated serials. %v", err)
+	}
+
+	if !reflect.DeepEqual(serials, []string{"1", "2"}) {
+		t.Errorf("all the serials should be returned without a tag, got %v", serials)
+	}
+
+	serials, _, err = s.UpdatedSerials(ctx, "device1", "pass.test", tag)
+	if err != nil || len(serials) != 0 {
+		t.Errorf("no serials should be updated since the last tag, got %v, %v", serials, err)
+	}
+
+	if err := s.PassUpdated(ctx, "pass.test", "2"); err != nil {
+		t.Fatalf("could not update pass. %v", err)
+	}
+
+	serials, next, err := s.UpdatedSerials(ctx, "device1", "pass.test", tag)
+	if err != nil || !reflect.DeepEqual(serials, []string{"2"}) {
+		t.Errorf("expected serial 2 to be updated, got %v, %v", serials, err)
+	}
+
+	if next == tag {
+		t.Errorf("the tag should change after an update")
+	}
+
+	serials, _, err = s.UpdatedSerials(ctx, "device1", "pass.test", next)
+	if err != nil || len(serials) != 0 {
+		t.Errorf("no serials should be updated since the new tag, got %v, %v", serials, err)
+	}
+
+	// Updates of the same pass always get a newer tag
+	if err := s.PassUpdated(ctx, "pass.test", "2"); err != nil {
+		t.Fatalf("could not update pass. %v", err)
+	}
+
+	serials, _, err = s.UpdatedSerials(ctx, "device1", "pass.test", next)
+	if err != nil || !reflect.DeepEqual(serials, []string{"2"}) {
+		t.Errorf("expected serial 2 to be updated again, got %v, %v", serials, err)
+	}
+
+	serials, _, err = s.UpdatedSerials(ctx, "device2", "pass.test", tag)
+	if err != nil || len(serials) != 0 {
+		t.Errorf("updates of passes not registered by the device should not be returned, got %v, %v", serials, err)
+	}
+
+	if err := s.Unregister(ctx, "device2", "pass.test", "1"); err != nil {
+		t.Fatalf("could not unregister. %v", err)
+	}
+
+	registrations, err = s.Registrations(ctx, "pass.test", "1")
+	if err != nil || len(registrations) != 1 || registrations[0].DeviceLibraryIdentifier != "device1" {
+		t.Errorf("device2 should be unregistered, got %v, %v", registrations, err)
+	}
+
+	// The push token of a device registered again after removing all its passes is the new one
+	if _, err := s.Register(ctx, "device2", "push-new", "pass.test", "2"); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	registrations, err = s.Registrations(ctx, "pass.test", "2")
+	if err != nil || !reflect.DeepEqual(registrations, []Registration{{"device1", "push-device1"}, {"device2", "push-new"}}) {
+		t.Errorf("unexpected registrations %v, %v", registrations, err)
+	}
+
+	if err := s.Unregister(ctx, "device3", "pass.test", "1"); err != nil {
+		t.Errorf("unregistering an unknown device should not fail. %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package passkit
+
+import (
+	"fmt"
+	"slices"
+	"time"
+)
+
+// relevantDatesValidationErrors validates every relevant date and checks that none of them overlap.
+func relevantDatesValidationErrors(owner string, dates []PassRelevantDate) []string {
+	var validationErrors []string
+	for _, prd := range dates {
+		if !prd.IsValid() {
+			validationErrors = append(validationErrors, prd.GetValidationErrors()...)
+		}
+	}
+
+	// Overlaps only make sense for well formed dates
+	if len(validationErrors) > 0 {
+		return validationErrors
+	}
+
+	sorted := slices.Clone(dates)
+	slices.SortFunc(sorted, func(a, b PassRelevantDate) int {
+		return a.start().Compare(b.start())
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		prev, cur := sorted[i-1], sorted[i]
+		if cur.start().Before(prev.end()) || (prev.Date != nil && cur.Date != nil && cur.Date.Equal(*prev.Date)) {
+			validationErrors = append(validationErrors, fmt.Sprintf("%s: The relevant dates %s and %s overlap", owner, prev, cur))
+		}
+	}
+
+	return validationErrors
+}
+
+// start returns when the relevant date begins, Date for a single date or StartDate for a range.
+func (prd PassRelevantDate) start() time.Time {
+	if prd.Date != nil {
+		return *prd.Date
+	}
+	if prd.StartDate != nil {
+		return *prd.StartDate
+	}
+	return time.Time{}
+}
+
+// end returns when the relevant date ends. A single date ends at the moment it begins.
+func (prd PassRelevantDate) end() time.Time {
+	if prd.EndDate != nil {
+		return *prd.EndDate
+	}
+	return prd.start()
+}
+
+func (prd PassRelevantDate) String() string {
+	if prd.Date != nil {
+		return prd.Date.Format(time.RFC3339)
+	}
+	return fmt.Sprintf("%s/%s", prd.start().Format(time.RFC3339), prd.end().Format(time.RFC3339))
+}
+
+// MigrateRelevantDates replaces the deprecated RelevantDate with an entry in RelevantDates. When the EventDetail
+// has an end date the entry is a range from the RelevantDate, or the event start date if not set, to the end of
+// the event. Otherwise it is a single date. Passes without RelevantDate that already have RelevantDates are left as
+// they are. An error is returned if the result has invalid or overlapping dates, in which case the pass is not
+// changed.
+func (p *Pass) MigrateRelevantDates() error {
+	// Nothing left to migrate
+	if p.RelevantDate == nil && len(p.RelevantDates) > 0 {
+		return nil
+	}
+
+	start := p.RelevantDate
+	var end *time.Time
+	if p.EventDetail != nil {
+		if start == nil {
+			start = p.EventDetail.EventStartDate
+		}
+		end = p.EventDetail.EventEndDate
+	}
+
+	if start == nil {
+		return nil
+	}
+
+	prd := PassRelevantDate{Date: cloneTime(start)}
+	if end != nil {
+		prd = PassRelevantDate{StartDate: cloneTime(start), EndDate: cloneTime(end)}
+	}
+
+	// Running the migration again doesn't add the same entry twice
+	if slices.ContainsFunc(p.RelevantDates, func(d PassRelevantDate) bool { return d.String() == prd.String() }) {
+		p.RelevantDate = nil
+		return nil
+	}
+
+	dates := append(slices.Clone(p.RelevantDates), prd)
+	if errs := relevantDatesValidationErrors("Pass", dates); len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	p.RelevantDates = dates
+	p.RelevantDate = nil
+	return nil
+}
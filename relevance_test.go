@@ -0,0 +1,112 @@
+package passkit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPassRelevantDate_StartAfterEnd(t *testing.T) {
+	prd := getBasicRelevantDate()
+	prd.StartDate, prd.EndDate = prd.EndDate, prd.StartDate
+
+	if prd.IsValid() {
+		t.Errorf("PassRelevantDate with StartDate after EndDate should be invalid")
+	}
+}
+
+func TestPass_OverlappingRelevantDates(t *testing.T) {
+	start := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	during := start.Add(time.Hour)
+	end := start.Add(2 * time.Hour)
+	after := end.Add(time.Hour)
+
+	pass := getBasicPass()
+	pass.RelevantDates = []PassRelevantDate{{Date: &after}, {StartDate: &start, EndDate: &end}}
+	if !pass.IsValid() {
+		t.Errorf("Pass should be valid. Reason: %v", pass.GetValidationErrors())
+	}
+
+	pass.RelevantDates = append(pass.RelevantDates, PassRelevantDate{Date: &during})
+	if len(pass.GetValidationErrors()) != 1 {
+		t.Errorf("Pass with a date inside a range should have 1 error, got %v", pass.GetValidationErrors())
+	}
+
+	pass.RelevantDates = nil
+	pass.Semantics = &SemanticTag{RelevantDates: []PassRelevantDate{{Date: &after}, {Date: &after}}}
+	if pass.IsValid() {
+		t.Errorf("Pass with repeated semantic relevant dates should be invalid")
+	}
+}
+
+func TestPass_RelevantDatesWarnings(t *testing.T) {
+	now := time.Now()
+	pass := getBasicPass()
+	pass.RelevantDate = &now
+	pass.Semantics = &SemanticTag{RelevantDates: []PassRelevantDate{{Date: &now}}}
+
+	if w := pass.GetValidationWarnings(); len(w) != 1 {
+		t.Errorf("Pass using relevantDate with the semantic relevantDates should have 1 warning, got %v", w)
+	}
+
+	pass.RelevantDates = []PassRelevantDate{{Date: &now}}
+	if w := pass.GetValidationWarnings(); len(w) != 3 {
+		t.Errorf("Pass using every relevant date form should have 3 warnings, got %v", w)
+	}
+}
+
+func TestPass_MigrateRelevantDates(t *testing.T) {
+	doors := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	start := doors.Add(time.Hour)
+	end := start.Add(2 * time.Hour)
+
+	pass := getBasicPass()
+	pass.RelevantDate = &doors
+	pass.EventDetail = &EventDetail{EventStartDate: &start, EventEndDate: &end}
+
+	if err := pass.MigrateRelevantDates(); err != nil {
+		t.Fatalf("Migration should not fail. %v", err)
+	}
+
+	if pass.RelevantDate != nil {
+		t.Errorf("RelevantDate should be removed")
+	}
+
+	if len(pass.RelevantDates) != 1 || !pass.RelevantDates[0].StartDate.Equal(doors) || !pass.RelevantDates[0].EndDate.Equal(end) {
+		t.Errorf("RelevantDates should be a range from the relevant date to the end of the event, got %v", pass.RelevantDates)
+	}
+
+	if err := pass.MigrateRelevantDates(); err != nil || len(pass.RelevantDates) != 1 {
+		t.Errorf("Migrating again should not change the pass. %v, %v", err, pass.RelevantDates)
+	}
+
+	pass = getBasicPass()
+	pass.EventDetail = &EventDetail{EventStartDate: &start}
+	if err := pass.MigrateRelevantDates(); err != nil || len(pass.RelevantDates) != 1 || !pass.RelevantDates[0].Date.Equal(start) {
+		t.Errorf("An event without end date should be migrated to a single date. %v, %v", err, pass.RelevantDates)
+	}
+}
+
+func TestPass_MigrateRelevantDatesOverlap(t *testing.T) {
+	start := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	during := start.Add(time.Hour)
+
+	pass := getBasicPass()
+	pass.RelevantDate = &start
+	pass.EventDetail = &EventDetail{EventEndDate: &end}
+	pass.RelevantDates = []PassRelevantDate{{Date: &during}}
+
+	if err := pass.MigrateRelevantDates(); err == nil {
+		t.Errorf("Migration with overlapping dates should fail")
+	}
+
+	if pass.RelevantDate == nil || len(pass.RelevantDates) != 1 {
+		t.Errorf("A failed migration should not change the pass")
+	}
+
+	end = start.Add(-time.Hour)
+	pass.RelevantDates = nil
+	if err := pass.MigrateRelevantDates(); err == nil {
+		t.Errorf("Migration with an event ending before the relevant date should fail")
+	}
+}
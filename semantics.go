@@ -1,6 +1,9 @@
 package passkit
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // SemanticTag Representation of https://developer.apple.com/documentation/walletpasses/semantictags
 type SemanticTag struct {
@@ -102,6 +105,20 @@ func (s *SemanticTag) IsValid() bool {
 func (s *SemanticTag) GetValidationErrors() []string {
 	var validationErrors []string
 	// Only validate what is validatable
+	for _, l := range []*SemanticTagLocation{s.DepartureLocation, s.DestinationLocation, s.VenueLocation} {
+		if l != nil && !l.IsValid() {
+			validationErrors = append(validationErrors, l.GetValidationErrors()...)
+		}
+	}
+
+	for _, amount := range []*SemanticTagCurrencyAmount{s.Balance, s.TotalPrice} {
+		if amount != nil && !amount.IsValid() {
+			validationErrors = append(validationErrors, amount.GetValidationErrors()...)
+		}
+	}
+
+	validationErrors = append(validationErrors, relevantDatesValidationErrors("SemanticTag", s.RelevantDates)...)
+
 	if s.WifiAccess != nil {
 		for _, wifiAccess := range s.WifiAccess {
 			if !wifiAccess.IsValid() {
@@ -132,7 +149,17 @@ func (s *SemanticTagCurrencyAmount) IsValid() bool {
 }
 
 func (s *SemanticTagCurrencyAmount) GetValidationErrors() []string {
-	return []string{}
+	var validationErrors []string
+
+	if !decimalAmountRegexp.MatchString(s.Amount) {
+		validationErrors = append(validationErrors, fmt.Sprintf("SemanticTagCurrencyAmount: The amount %q is not a decimal number", s.Amount))
+	}
+
+	if !isCurrencyCode(s.CurrencyCode) {
+		validationErrors = append(validationErrors, fmt.Sprintf("SemanticTagCurrencyAmount: The currencyCode %q is not an ISO 4217 currency code", s.CurrencyCode))
+	}
+
+	return validationErrors
 }
 
 // SemanticTagLocation Representation of https://developer.apple.com/documentation/walletpasses/semantictagtype/location
@@ -146,7 +173,7 @@ func (l *SemanticTagLocation) IsValid() bool {
 }
 
 func (l *SemanticTagLocation) GetValidationErrors() []string {
-	return []string{}
+	return validateCoordinates("SemanticTagLocation", l.Latitude, l.Longitude)
 }
 
 // SemanticTagPersonNameComponents Representation of https://developer.apple.com/documentation/walletpasses/semantictagtype/personnamecomponents
@@ -203,3 +230,89 @@ func (w *SemanticTagWifiNetwork) GetValidationErrors() []string {
 	}
 	return validationErrors
 }
+
+// semanticStyleRule restricts a group of semantic tags, by JSON name, to the pass styles they are meant for.
+type semanticStyleRule struct {
+	tags     []string
+	meantFor string
+	allowed  func(p *Pass, eventType EventType) bool
+}
+
+var semanticStyleRules = []semanticStyleRule{
+	{
+		tags: []string{"airlineCode", "flightCode", "flightNumber", "departureAirportCode", "departureAirportName",
+			"destinationAirportCode", "destinationAirportName", "departureGate", "destinationGate", "departureTerminal",
+			"destinationTerminal", "boardingGroup", "boardingSequenceNumber", "priorityStatus", "securityScreening"},
+		meantFor: "boarding passes with transit type " + string(TransitTypeAir),
+		allowed: func(p *Pass, _ EventType) bool {
+			return p.BoardingPass != nil && p.BoardingPass.TransitType == TransitTypeAir
+		},
+	},
+	{
+		tags: []string{"transitProvider", "transitStatus", "transitStatusReason", "vehicleName", "vehicleNumber",
+			"vehicleType", "carNumber", "departurePlatform", "destinationPlatform", "departureStationName",
+			"destinationStationName", "departureLocation", "departureLocationDescription", "destinationLocation",
+			"destinationLocationDescription", "originalDepartureDate", "currentDepartureDate", "originalArrivalDate",
+			"currentArrivalDate", "originalBoardingDate", "currentBoardingDate", "passengerName"},
+		meantFor: "boarding passes",
+		allowed: func(p *Pass, _ EventType) bool {
+			return p.BoardingPass != nil
+		},
+	},
+	{
+		tags: []string{"homeTeamAbbreviation", "homeTeamLocation", "homeTeamName", "awayTeamAbbreviation",
+			"awayTeamLocation", "awayTeamName", "leagueAbbreviation", "leagueName", "sportName"},
+		meantFor: "event tickets with event type " + string(EventTypeSports),
+		allowed: func(p *Pass, eventType EventType) bool {
+			return p.EventTicket != nil && eventType == EventTypeSports
+		},
+	},
+	{
+		tags: []string{"eventName", "eventType", "eventStartDate", "eventEndDate", "eventStartDateInfo",
+			"eventLiveMessage", "performerNames", "artistIDs", "albumIDs", "playlistIDs", "genre", "admissionLevel",
+			"admissionLevelAbbreviation", "attendeeName", "entranceDescription", "additionalTicketAttributes",
+			"silenceRequested", "tailgatingAllowed", "venueName", "venueLocation", "venuePhoneNumber", "venueRoom",
+			"venueRegionName", "venueEntrance", "venueEntranceDoor", "venueEntranceGate", "venueEntrancePortal",
+			"venueOpenDate", "venueCloseDate", "venueDoorsOpenDate", "venueGatesOpenDate", "venueBoxOfficeOpenDate",
+			"venueFanZoneOpenDate", "venueParkingLotsOpenDate"},
+		meantFor: "event tickets",
+		allowed: func(p *Pass, _ EventType) bool {
+			return p.EventTicket != nil
+		},
+	},
+	{
+		tags:     []string{"balance"},
+		meantFor: "store cards",
+		allowed: func(p *Pass, _ EventType) bool {
+			return p.StoreCard != nil
+		},
+	},
+}
+
+// semanticStyleWarnings checks that the tags set in s are meant for the style of the pass.
+func (p *Pass) semanticStyleWarnings(owner string, s *SemanticTag) []string {
+	props, err := jsonProperties(s)
+	if err != nil {
+		return []string{fmt.Sprintf("%s: Could not read the semantic tags. %v", owner, err)}
+	}
+
+	eventType := s.EventType
+	if eventType == "" && p.Semantics != nil {
+		eventType = p.Semantics.EventType
+	}
+
+	var warnings []string
+	for _, rule := range semanticStyleRules {
+		if rule.allowed(p, eventType) {
+			continue
+		}
+
+		for _, tag := range rule.tags {
+			if _, ok := props[tag]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s: The semantic tag %q is meant for %s", owner, tag, rule.meantFor))
+			}
+		}
+	}
+
+	return warnings
+}
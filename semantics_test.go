@@ -0,0 +1,98 @@
+package passkit
+
+import (
+	"testing"
+)
+
+func TestSemanticTagCurrencyAmount_Valid(t *testing.T) {
+	amount := SemanticTagCurrencyAmount{Amount: "12.50", CurrencyCode: "EUR"}
+
+	if !amount.IsValid() {
+		t.Errorf("SemanticTagCurrencyAmount should be valid. Reason: %v", amount.GetValidationErrors())
+	}
+}
+
+func TestSemanticTagCurrencyAmount_Invalid(t *testing.T) {
+	amount := SemanticTagCurrencyAmount{Amount: "12,50", CurrencyCode: "EURO"}
+
+	t.Logf("%d, %v", len(amount.GetValidationErrors()), amount.GetValidationErrors())
+	if amount.IsValid() {
+		t.Errorf("SemanticTagCurrencyAmount should be invalid")
+	}
+
+	if len(amount.GetValidationErrors()) != 2 {
+		t.Errorf("SemanticTagCurrencyAmount should have two errors. Have: %v", len(amount.GetValidationErrors()))
+	}
+
+	amount = SemanticTagCurrencyAmount{Amount: "1", CurrencyCode: "XYZ"}
+	if amount.IsValid() {
+		t.Errorf("XYZ is not an ISO 4217 currency code")
+	}
+}
+
+func TestSemanticTag_InvalidAmounts(t *testing.T) {
+	s := SemanticTag{
+		Balance:    &SemanticTagCurrencyAmount{Amount: "abc", CurrencyCode: "USD"},
+		TotalPrice: &SemanticTagCurrencyAmount{Amount: "10", CurrencyCode: "usd"},
+	}
+
+	t.Logf("%d, %v", len(s.GetValidationErrors()), s.GetValidationErrors())
+	if len(s.GetValidationErrors()) != 2 {
+		t.Errorf("SemanticTag should have two errors. Have: %v", len(s.GetValidationErrors()))
+	}
+}
+
+func TestPass_SemanticStyleWarnings(t *testing.T) {
+	pass := getBasicPass()
+	pass.Generic = nil
+	pass.BoardingPass = NewBoardingPass(TransitTypeAir)
+	pass.BoardingPass.AddPrimaryFields(Field{Key: "from", Value: "LAX", Semantics: &SemanticTag{DepartureAirportCode: "LAX"}})
+	pass.Semantics = &SemanticTag{AirlineCode: "AA", TransitProvider: "American Airlines"}
+
+	if len(pass.GetValidationWarnings()) != 0 {
+		t.Errorf("Air boarding pass should have no warnings. Have: %v", pass.GetValidationWarnings())
+	}
+
+	pass.BoardingPass.TransitType = TransitTypeTrain
+
+	t.Logf("%d, %v", len(pass.GetValidationWarnings()), pass.GetValidationWarnings())
+	if len(pass.GetValidationWarnings()) != 2 {
+		t.Errorf("Train boarding pass with airline tags should have two warnings. Have: %v", len(pass.GetValidationWarnings()))
+	}
+
+	if !pass.IsValid() {
+		t.Errorf("Semantic style mismatches should not be errors. Have: %v", pass.GetValidationErrors())
+	}
+}
+
+func TestPass_SportsSemanticWarnings(t *testing.T) {
+	pass := getBasicPass()
+	pass.Generic = nil
+	pass.EventTicket = NewEventTicket()
+	pass.Semantics = &SemanticTag{EventName: "Final", HomeTeamName: "Home", AwayTeamName: "Away"}
+
+	t.Logf("%d, %v", len(pass.GetValidationWarnings()), pass.GetValidationWarnings())
+	if len(pass.GetValidationWarnings()) != 2 {
+		t.Errorf("Event ticket without the sports event type should have two warnings. Have: %v", len(pass.GetValidationWarnings()))
+	}
+
+	pass.Semantics.EventType = EventTypeSports
+	if len(pass.GetValidationWarnings()) != 0 {
+		t.Errorf("Sports event ticket should have no warnings. Have: %v", pass.GetValidationWarnings())
+	}
+}
+
+func TestPass_BalanceSemanticWarnings(t *testing.T) {
+	pass := getBasicPass()
+	pass.Semantics = &SemanticTag{Balance: &SemanticTagCurrencyAmount{Amount: "10", CurrencyCode: "USD"}}
+
+	if len(pass.GetValidationWarnings()) != 1 {
+		t.Errorf("Generic pass with a balance should have one warning. Have: %v", pass.GetValidationWarnings())
+	}
+
+	pass.StoreCard = &StoreCard{GenericPass: pass.Generic}
+	pass.Generic = nil
+	if len(pass.GetValidationWarnings()) != 0 {
+		t.Errorf("Store card with a balance should have no warnings. Have: %v", pass.GetValidationWarnings())
+	}
+}
@@ -0,0 +1,103 @@
+package passkit
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ServePass writes a signed pass to w, with the pass MIME type and a file name made from its serial number. The
+// ETag is the hash of the pass manifest, so it only changes when the contents of the pass change. Conditional
+// requests with If-None-Match or If-Modified-Since, which devices send when checking for updated passes, are
+// answered with 304 Not Modified.
+func ServePass(w http.ResponseWriter, r *http.Request, archive PassArchive, lastModified time.Time) {
+	name := "pass.pkpass"
+	if serialNumber := archiveSerialNumber(archive); serialNumber != "" {
+		name = serialNumber + ".pkpass"
+	}
+
+	serveArchive(w, r, archive, name, PassMimeType, archiveETag(archive), lastModified)
+}
+
+// ServePassBundle writes a bundle of signed passes to w, with the pass bundle MIME type. It handles conditional
+// requests like ServePass, with an ETag made from the names and manifests of the bundled passes.
+func ServePassBundle(w http.ResponseWriter, r *http.Request, bundle PassBundleArchive, lastModified time.Time) {
+	h := sha256.New()
+	files, err := ReadArchiveFiles(bundle)
+	if err != nil {
+		h.Write(bundle)
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(archiveETag(files[name])))
+	}
+
+	serveArchive(w, r, bundle, "passes.pkpasses", PassBundleMimeType, fmt.Sprintf(`"%x"`, h.Sum(nil)), lastModified)
+}
+
+func serveArchive(w http.ResponseWriter, r *http.Request, archive []byte, name, mimeType, etag string, lastModified time.Time) {
+	w.Header().Set("Content-Type", mimeType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.Header().Set("ETag", etag)
+
+	http.ServeContent(w, r, name, lastModified, bytes.NewReader(archive))
+}
+
+// archiveETag returns a quoted ETag with the SHA-256 hash of the manifest of a signed archive, or of the whole
+// archive if it has no manifest.
+func archiveETag(archive []byte) string {
+	content := archive
+	if mfst := readArchiveFile(archive, manifestJsonFileName); mfst != nil {
+		content = mfst
+	}
+
+	return fmt.Sprintf(`"%x"`, sha256.Sum256(content))
+}
+
+// archiveSerialNumber returns the serial number in the pass.json of a signed archive, if it can be read.
+func archiveSerialNumber(archive []byte) string {
+	var p struct {
+		SerialNumber string `json:"serialNumber"`
+	}
+	if err := json.Unmarshal(readArchiveFile(archive, passJsonFileName), &p); err != nil {
+		return ""
+	}
+
+	return p.SerialNumber
+}
+
+// readArchiveFile returns the contents of the named file in a zip archive, or nil if it can't be read.
+func readArchiveFile(archive []byte, name string) []byte {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil
+	}
+
+	f, err := r.Open(name)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	b, err := io.ReadAll(f)
+	if err != nil {
+		return nil
+	}
+
+	return b
+}
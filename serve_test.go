@@ -0,0 +1,163 @@
+package passkit
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestServePass(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	signer := NewMemoryBasedSigner()
+	z, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	modified := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	w := httptest.NewRecorder()
+	ServePass(w, httptest.NewRequest(http.MethodGet, "/pass", nil), z, modified)
+
+	if w.Code != http.StatusOK || w.Body.Len() != len(z) {
+		t.Fatalf("Pass should be served, got %d", w.Code)
+	}
+
+	headers := map[string]string{
+		"Content-Type":        PassMimeType,
+		"Content-Disposition": `attachment; filename=` + pass.SerialNumber + `.pkpass`,
+		"Last-Modified":       modified.Format(http.TimeFormat),
+	}
+	for name, value := range headers {
+		if w.Header().Get(name) != value {
+			t.Errorf("%s should be %q, got %q", name, value, w.Header().Get(name))
+		}
+	}
+
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatalf("Pass should have an ETag")
+	}
+
+	// Signing the same pass again changes the signature, but not the manifest
+	z2, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	if archiveETag(z2) != etag {
+		t.Errorf("ETag should only depend on the manifest")
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/pass", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	ServePass(w, r, z, modified)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match with the ETag should return 304, got %d", w.Code)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/pass", nil)
+	r.Header.Set("If-Modified-Since", modified.Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	ServePass(w, r, z, modified)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("If-Modified-Since the last modification should return 304, got %d", w.Code)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/pass", nil)
+	r.Header.Set("If-Modified-Since", modified.Add(-time.Hour).Format(http.TimeFormat))
+	w = httptest.NewRecorder()
+	ServePass(w, r, z, modified)
+	if w.Code != http.StatusOK {
+		t.Errorf("If-Modified-Since before the last modification should return 200, got %d", w.Code)
+	}
+
+	pass.Description = "changed"
+	z3, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	r = httptest.NewRequest(http.MethodGet, "/pass", nil)
+	r.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	ServePass(w, r, z3, modified)
+	if w.Code != http.StatusOK {
+		t.Errorf("If-None-Match with the ETag of a previous version should return 200, got %d", w.Code)
+	}
+}
+
+func TestServePassBundle(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	signer := NewMemoryBasedSigner()
+	z, err := signer.CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	b, err := signer.CreatePassBundleArchive(z, z)
+	if err != nil {
+		t.Fatalf("could not bundle passes. %v", err)
+	}
+
+	w := httptest.NewRecorder()
+	ServePassBundle(w, httptest.NewRequest(http.MethodGet, "/passes", nil), b, time.Now())
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != PassBundleMimeType || w.Header().Get("ETag") == "" {
+		t.Fatalf("Bundle should be served, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/passes", nil)
+	r.Header.Set("If-None-Match", w.Header().Get("ETag"))
+	w = httptest.NewRecorder()
+	ServePassBundle(w, r, b, time.Now())
+	if w.Code != http.StatusNotModified {
+		t.Errorf("If-None-Match with the ETag should return 304, got %d", w.Code)
+	}
+}
+
+func TestServePassBundle_EntryNames(t *testing.T) {
+	// bundle zips the name and content pairs, in order
+	bundle := func(entries ...string) PassBundleArchive {
+		buf := new(bytes.Buffer)
+		zw := zip.NewWriter(buf)
+		for i := 0; i < len(entries); i += 2 {
+			f, err := zw.Create(entries[i])
+			if err != nil {
+				t.Fatalf("could not create zip entry. %v", err)
+			}
+			_, _ = f.Write([]byte(entries[i+1]))
+		}
+
+		if err := zw.Close(); err != nil {
+			t.Fatalf("could not close zip. %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	etag := func(b PassBundleArchive) string {
+		w := httptest.NewRecorder()
+		ServePassBundle(w, httptest.NewRequest(http.MethodGet, "/passes", nil), b, time.Now())
+		return w.Header().Get("ETag")
+	}
+
+	first := etag(bundle("a.pkpass", "first"))
+	second := etag(bundle("a.pkpass", "second"))
+	renamed := etag(bundle("b.pkpass", "first"))
+
+	if first == second || first == renamed {
+		t.Errorf("Bundles with other entry names should have different ETags, got %s %s %s", first, second, renamed)
+	}
+
+	if etag(bundle("a.pkpass", "first", "b.pkpass", "second")) != etag(bundle("b.pkpass", "second", "a.pkpass", "first")) {
+		t.Errorf("The ETag should not depend on the order of the entries")
+	}
+}
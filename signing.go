@@ -1,12 +1,15 @@
 package passkit
 
 import (
+	"crypto"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
 	"crypto/x509"
 	"encoding/asn1"
 	"errors"
 	"fmt"
 	"os"
-	"time"
 
 	"go.mozilla.org/pkcs7"
 	"golang.org/x/crypto/pkcs12"
@@ -32,6 +35,93 @@ type Signer interface {
 	SignManifestFile(manifestJson []byte, i *SigningInformation) ([]byte, error)
 }
 
+// SignerOption configures the signers returned by NewMemoryBasedSigner and NewFileBasedSigner.
+type SignerOption func(o *signerOptions)
+
+type signerOptions struct {
+	warningHandler   func(p *Pass, warnings ValidationFindings)
+	manifestHash     crypto.Hash
+	digestAlgorithm  crypto.Hash
+	signedAttributes []SignedAttribute
+	// timestampAuthority timestamps the signature if set
+	timestampAuthority TimestampAuthority
+	// signingRegistry provides the signing information when none is given
+	signingRegistry *SigningRegistry
+}
+
+// SignedAttribute is an attribute added to the signed attributes of the manifest signature. Value is marshalled to
+// ASN.1 with encoding/asn1.
+type SignedAttribute struct {
+	Type  asn1.ObjectIdentifier
+	Value interface{}
+}
+
+// WithWarningHandler makes the signer call handler with the validation warnings of every pass it signs. Passes
+// with warnings are still signed, only validation errors make signing fail.
+func WithWarningHandler(handler func(p *Pass, warnings ValidationFindings)) SignerOption {
+	return func(o *signerOptions) {
+		o.warningHandler = handler
+	}
+}
+
+// WithManifestHash sets the algorithm used to hash the files listed in manifest.json. Passes use SHA-1 by default,
+// and orders SHA-256. SHA-1, SHA-256, SHA-384 and SHA-512 are supported.
+func WithManifestHash(h crypto.Hash) SignerOption {
+	return func(o *signerOptions) {
+		o.manifestHash = h
+	}
+}
+
+// WithDigestAlgorithm sets the digest algorithm of the PKCS#7 signature of the manifest, independently of the
+// manifest hash. Passes use SHA-1 by default, and orders SHA-256. SHA-1, SHA-256, SHA-384 and SHA-512 are
+// supported.
+func WithDigestAlgorithm(h crypto.Hash) SignerOption {
+	return func(o *signerOptions) {
+		o.digestAlgorithm = h
+	}
+}
+
+// WithSignedAttributes adds custom attributes to the signed attributes of the manifest signature. The contentType,
+// messageDigest and signingTime attributes are always added by the signer, and can't be set with this option.
+func WithSignedAttributes(attrs ...SignedAttribute) SignerOption {
+	return func(o *signerOptions) {
+		o.signedAttributes = append(o.signedAttributes, attrs...)
+	}
+}
+
+func newSignerOptions(opts []SignerOption) signerOptions {
+	return applySignerOptions(signerOptions{manifestHash: crypto.SHA1, digestAlgorithm: crypto.SHA1}, opts)
+}
+
+func applySignerOptions(o signerOptions, opts []SignerOption) signerOptions {
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// checkFindings returns an error if there are validation errors, otherwise it hands the warnings, if any, to the
+// warning handler.
+func (o signerOptions) checkFindings(p *Pass, findings ValidationFindings) error {
+	if err := findings.Err(); err != nil {
+		return err
+	}
+
+	var warnings ValidationFindings
+	for _, f := range findings {
+		if f.Severity == SeverityWarning {
+			warnings = append(warnings, f)
+		}
+	}
+
+	if len(warnings) > 0 && o.warningHandler != nil {
+		o.warningHandler(p, warnings)
+	}
+
+	return nil
+}
+
 type SigningInformation struct {
 	signingCert     *x509.Certificate
 	appleWWDRCACert *x509.Certificate
@@ -104,25 +194,52 @@ func verify(cert *x509.Certificate) error {
 	}
 }
 
-func signManifestFile(manifestJson []byte, i *SigningInformation) ([]byte, error) {
+// digestAlgorithmOIDs are the PKCS#7 identifiers of the supported hash algorithms.
+var digestAlgorithmOIDs = map[crypto.Hash]asn1.ObjectIdentifier{
+	crypto.SHA1:   pkcs7.OIDDigestAlgorithmSHA1,
+	crypto.SHA256: pkcs7.OIDDigestAlgorithmSHA256,
+	crypto.SHA384: pkcs7.OIDDigestAlgorithmSHA384,
+	crypto.SHA512: pkcs7.OIDDigestAlgorithmSHA512,
+}
+
+func checkHashAlgorithm(name string, h crypto.Hash) error {
+	if _, ok := digestAlgorithmOIDs[h]; !ok || !h.Available() {
+		return fmt.Errorf("unsupported %s algorithm %v", name, h)
+	}
+
+	return nil
+}
+
+func signManifestFile(manifestJson []byte, i *SigningInformation, o signerOptions) ([]byte, error) {
 	if manifestJson == nil {
 		return nil, fmt.Errorf("manifestJson has to be present")
 	}
 
+	if i == nil {
+		return nil, fmt.Errorf("signing information has to be present")
+	}
+
+	if err := checkHashAlgorithm("digest", o.digestAlgorithm); err != nil {
+		return nil, err
+	}
+
 	s, err := pkcs7.NewSignedData(manifestJson)
 	if err != nil {
 		return nil, err
 	}
 
+	s.SetDigestAlgorithm(digestAlgorithmOIDs[o.digestAlgorithm])
+
 	s.AddCertificate(i.appleWWDRCACert)
 
-	signingTimeAttr, err := createSigningTimeAttribute()
-	if err != nil {
-		return nil, err
-	}
+	// AddSigner adds the contentType, messageDigest and signingTime attributes, only the custom ones are added here
+	signerInfoConfig := pkcs7.SignerInfoConfig{}
+	for _, attr := range o.signedAttributes {
+		if isReservedSignedAttribute(attr.Type) {
+			return nil, fmt.Errorf("signed attribute %v is set by the signer and cannot be overridden", attr.Type)
+		}
 
-	signerInfoConfig := pkcs7.SignerInfoConfig{
-		ExtraSignedAttributes: []pkcs7.Attribute{signingTimeAttr},
+		signerInfoConfig.ExtraSignedAttributes = append(signerInfoConfig.ExtraSignedAttributes, pkcs7.Attribute{Type: attr.Type, Value: attr.Value})
 	}
 
 	err = s.AddSigner(i.signingCert, i.privateKey, signerInfoConfig)
@@ -130,18 +247,16 @@ func signManifestFile(manifestJson []byte, i *SigningInformation) ([]byte, error
 		return nil, err
 	}
 
+	if o.timestampAuthority != nil {
+		if err := timestampSignature(s, o.timestampAuthority, crypto.SHA256); err != nil {
+			return nil, err
+		}
+	}
+
 	s.Detach()
 	return s.Finish()
 }
 
-func createSigningTimeAttribute() (pkcs7.Attribute, error) {
-	signingTimeBytes, err := asn1.Marshal(time.Now().UTC())
-	if err != nil {
-		return pkcs7.Attribute{}, err
-	}
-
-	return pkcs7.Attribute{
-		Type:  asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2},
-		Value: signingTimeBytes,
-	}, nil
+func isReservedSignedAttribute(oid asn1.ObjectIdentifier) bool {
+	return oid.Equal(pkcs7.OIDAttributeContentType) || oid.Equal(pkcs7.OIDAttributeMessageDigest) || oid.Equal(pkcs7.OIDAttributeSigningTime)
 }
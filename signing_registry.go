@@ -0,0 +1,220 @@
+package passkit
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultSigningRegistryPollInterval = time.Minute
+
+// oidUserID is the subject attribute of the Wallet certificates that holds their pass type identifier.
+var oidUserID = asn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 1}
+
+// ErrNoSigningInformation is returned when signing a pass or order with a SigningRegistry that has no credentials
+// for its type identifier.
+var ErrNoSigningInformation = errors.New("no signing information registered")
+
+// errSigningRegistryUnchanged is returned by the loaders of a SigningRegistry when the credentials didn't change
+// since they were last loaded.
+var errSigningRegistryUnchanged = errors.New("signing information unchanged")
+
+// SigningRegistry holds the signing credentials of several pass or order types, by type identifier. Signers created
+// with WithSigningRegistry use it when they are not given SigningInformation.
+type SigningRegistry struct {
+	// PollInterval is how often Run reloads the credentials, one minute by default.
+	PollInterval time.Duration
+
+	load func() (map[string]*SigningInformation, error)
+	// reloadMu serializes the calls to load
+	reloadMu sync.Mutex
+	mu       sync.RWMutex
+	infos    map[string]*SigningInformation
+}
+
+// WithSigningRegistry makes the signer use the credentials registered for the pass type identifier of the pass,
+// or the order type identifier of the order, when the SigningInformation passed to it is nil.
+func WithSigningRegistry(r *SigningRegistry) SignerOption {
+	return func(o *signerOptions) {
+		o.signingRegistry = r
+	}
+}
+
+// NewSigningRegistry creates an empty SigningRegistry, to fill with Register.
+func NewSigningRegistry() *SigningRegistry {
+	return &SigningRegistry{PollInterval: defaultSigningRegistryPollInterval, infos: make(map[string]*SigningInformation)}
+}
+
+// NewSigningRegistryFromFunc creates a SigningRegistry with the credentials returned by load, by type identifier.
+// load is called again by Reload and Run, so renewed certificates are picked up.
+func NewSigningRegistryFromFunc(load func() (map[string]*SigningInformation, error)) (*SigningRegistry, error) {
+	r := NewSigningRegistry()
+	r.load = load
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// LoadSigningRegistryFromDirectory creates a SigningRegistry with the PKCS#12 key stores in dir, named after their
+// pass type identifier, like pass.com.example.p12, which has to match the UID of the subject of their certificate.
+// password returns the password of the key store of each pass type identifier. The files are loaded again by Reload
+// and Run when they change.
+func LoadSigningRegistryFromDirectory(dir, appleWWDRCAFilePath string, password func(passTypeIdentifier string) string) (*SigningRegistry, error) {
+	var fingerprint string
+
+	return NewSigningRegistryFromFunc(func() (map[string]*SigningInformation, error) {
+		current, err := sourceFingerprint(appleWWDRCAFilePath, dir)
+		if err != nil {
+			return nil, err
+		}
+
+		if current == fingerprint {
+			return nil, errSigningRegistryUnchanged
+		}
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, err
+		}
+
+		infos := make(map[string]*SigningInformation)
+		for _, e := range entries {
+			typeIdentifier, ok := strings.CutSuffix(e.Name(), ".p12")
+			if !ok || e.IsDir() {
+				continue
+			}
+
+			info, err := LoadSigningInformationFromFiles(filepath.Join(dir, e.Name()), password(typeIdentifier), appleWWDRCAFilePath)
+			if err != nil {
+				return nil, fmt.Errorf("could not load signing information of %s: %w", typeIdentifier, err)
+			}
+
+			if uid := certificateUserID(info.signingCert); uid != typeIdentifier {
+				return nil, fmt.Errorf("could not load signing information of %s: the certificate of %s is for %q", typeIdentifier, e.Name(), uid)
+			}
+
+			infos[typeIdentifier] = info
+		}
+
+		fingerprint = current
+		return infos, nil
+	})
+}
+
+// Register sets the credentials of a type identifier. Registries created from a directory or function replace them
+// on the next reload.
+func (r *SigningRegistry) Register(typeIdentifier string, info *SigningInformation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.infos[typeIdentifier] = info
+}
+
+// SigningInformation returns the credentials of a type identifier, or ErrNoSigningInformation.
+func (r *SigningRegistry) SigningInformation(typeIdentifier string) (*SigningInformation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.infos[typeIdentifier]
+	if !ok {
+		return nil, fmt.Errorf("%w for %q", ErrNoSigningInformation, typeIdentifier)
+	}
+
+	return info, nil
+}
+
+// TypeIdentifiers returns the type identifiers with registered credentials.
+func (r *SigningRegistry) TypeIdentifiers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ids := make([]string, 0, len(r.infos))
+	for id := range r.infos {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// Reload loads the credentials again from the directory or function the registry was created with. If they can't be
+// loaded the previous credentials are kept.
+func (r *SigningRegistry) Reload() error {
+	if r.load == nil {
+		return nil
+	}
+
+	r.reloadMu.Lock()
+	defer r.reloadMu.Unlock()
+
+	infos, err := r.load()
+	if errors.Is(err, errSigningRegistryUnchanged) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.infos = infos
+	return nil
+}
+
+// Run reloads the credentials every PollInterval, until ctx is done. Reload errors are logged.
+func (r *SigningRegistry) Run(ctx context.Context) {
+	interval := r.PollInterval
+	if interval <= 0 {
+		interval = defaultSigningRegistryPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Reload(); err != nil {
+				slog.Error("could not reload signing information", "error", err)
+			}
+		}
+	}
+}
+
+// certificateUserID returns the UID of the subject of cert, or an empty string if it has none.
+func certificateUserID(cert *x509.Certificate) string {
+	for _, n := range cert.Subject.Names {
+		if n.Type.Equal(oidUserID) {
+			if uid, ok := n.Value.(string); ok {
+				return uid
+			}
+		}
+	}
+
+	return ""
+}
+
+// signingInformation returns i, or the credentials registered for typeIdentifier if i is nil.
+func (o signerOptions) signingInformation(typeIdentifier string, i *SigningInformation) (*SigningInformation, error) {
+	if i != nil {
+		return i, nil
+	}
+
+	if o.signingRegistry == nil {
+		return nil, errors.New("signing information is required when the signer has no signing registry")
+	}
+
+	return o.signingRegistry.SigningInformation(typeIdentifier)
+}
@@ -0,0 +1,166 @@
+package passkit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSigningRegistry_Signer(t *testing.T) {
+	r := NewSigningRegistry()
+	r.Register("pass.com.example", newTestSigningInformation(t))
+
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	pass := getBasicPass()
+	pass.PassTypeIdentifier = "pass.com.example"
+
+	for name, signer := range map[string]Signer{
+		"memory": NewMemoryBasedSigner(WithSigningRegistry(r)),
+		"file":   NewFileBasedSigner(WithSigningRegistry(r)),
+	} {
+		z, err := signer.CreateSignedAndZippedPassArchive(&pass, template, nil)
+		if err != nil {
+			t.Fatalf("%s: could not sign pass with the registry. %v", name, err)
+		}
+
+		if err := VerifyPassArchive(z); err != nil {
+			t.Errorf("%s: invalid pass archive. %v", name, err)
+		}
+
+		other := getBasicPass()
+		other.PassTypeIdentifier = "pass.com.unknown"
+		_, err = signer.CreateSignedAndZippedPassArchive(&other, template, nil)
+		if !errors.Is(err, ErrNoSigningInformation) || !strings.Contains(err.Error(), "pass.com.unknown") {
+			t.Errorf("%s: expected ErrNoSigningInformation naming the pass type, got %v", name, err)
+		}
+	}
+
+	if _, err := NewMemoryBasedSigner().CreateSignedAndZippedPassArchive(&pass, template, nil); err == nil {
+		t.Errorf("signing without signing information or registry should fail")
+	}
+}
+
+func TestSigningRegistry_Reload(t *testing.T) {
+	first := newTestSigningInformation(t)
+	second := newTestSigningInformation(t)
+
+	current := first
+	var fail bool
+	r, err := NewSigningRegistryFromFunc(func() (map[string]*SigningInformation, error) {
+		if fail {
+			return nil, errors.New("certificate store unavailable")
+		}
+		return map[string]*SigningInformation{"pass.com.example": current}, nil
+	})
+	if err != nil {
+		t.Fatalf("could not create registry. %v", err)
+	}
+
+	if info, _ := r.SigningInformation("pass.com.example"); info != first {
+		t.Errorf("the loaded credentials should be registered")
+	}
+
+	current = second
+	if err := r.Reload(); err != nil {
+		t.Fatalf("could not reload. %v", err)
+	}
+
+	if info, _ := r.SigningInformation("pass.com.example"); info != second {
+		t.Errorf("renewed credentials should be used after reloading")
+	}
+
+	fail = true
+	if err := r.Reload(); err == nil {
+		t.Errorf("reload errors should be returned")
+	}
+
+	if info, _ := r.SigningInformation("pass.com.example"); info != second {
+		t.Errorf("the previous credentials should be kept when reloading fails")
+	}
+}
+
+func TestLoadSigningRegistryFromDirectory(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join("test", "passbook", "ca.pem")
+
+	if err := os.WriteFile(filepath.Join(dir, "README"), []byte("not a key store"), 0644); err != nil {
+		t.Fatalf("could not write file. %v", err)
+	}
+
+	var passwords []string
+	r, err := LoadSigningRegistryFromDirectory(dir, ca, func(passTypeIdentifier string) string {
+		passwords = append(passwords, passTypeIdentifier)
+		return "password"
+	})
+	if err != nil {
+		t.Fatalf("could not load registry. %v", err)
+	}
+
+	if len(r.TypeIdentifiers()) != 0 {
+		t.Errorf("only .p12 files should be loaded, got %v", r.TypeIdentifiers())
+	}
+
+	if err := r.Reload(); err != nil || len(passwords) != 0 {
+		t.Errorf("reloading an unchanged directory should do nothing, got %v %v", passwords, err)
+	}
+
+	p12, err := os.ReadFile(filepath.Join("test", "passbook", "passkit.p12"))
+	if err != nil {
+		t.Fatalf("could not read key store. %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "pass.com.example.p12"), p12, 0644); err != nil {
+		t.Fatalf("could not write key store. %v", err)
+	}
+
+	// The key store of the tests has an expired certificate, so loading it fails naming the pass type
+	err = r.Reload()
+	if len(passwords) != 1 || passwords[0] != "pass.com.example" {
+		t.Errorf("the password of the pass type should be requested, got %v", passwords)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "pass.com.example") {
+		t.Errorf("load errors should name the pass type, got %v", err)
+	}
+}
+
+func TestLoadSigningRegistryFromDirectory_UserID(t *testing.T) {
+	dir := t.TempDir()
+	ca := filepath.Join("test", "passbook", "testwwdrca.cer")
+
+	p12, err := os.ReadFile(filepath.Join("test", "passbook", "pass.com.example.p12"))
+	if err != nil {
+		t.Fatalf("could not read key store. %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "pass.com.example.p12"), p12, 0644); err != nil {
+		t.Fatalf("could not write key store. %v", err)
+	}
+
+	password := func(string) string { return "password" }
+	r, err := LoadSigningRegistryFromDirectory(dir, ca, password)
+	if err != nil {
+		t.Fatalf("could not load registry. %v", err)
+	}
+
+	if ids := r.TypeIdentifiers(); len(ids) != 1 || ids[0] != "pass.com.example" {
+		t.Errorf("the key store should be registered by its pass type identifier, got %v", ids)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "pass.com.other.p12"), p12, 0644); err != nil {
+		t.Fatalf("could not write key store. %v", err)
+	}
+
+	err = r.Reload()
+	if err == nil || !strings.Contains(err.Error(), "pass.com.other.p12") {
+		t.Errorf("a misnamed key store should fail naming the file, got %v", err)
+	}
+
+	if _, err := r.SigningInformation("pass.com.other"); err == nil {
+		t.Errorf("the credentials of a misnamed key store should not be registered")
+	}
+}
@@ -1,18 +1,89 @@
 package passkit
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"go.mozilla.org/pkcs7"
 )
 
+// newTestCertificate creates a certificate for key, signed by parent and parentKey. If parent is nil the
+// certificate is self-signed.
+func newTestCertificate(t *testing.T, cn string, key *ecdsa.PrivateKey, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("could not create serial number. %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	if parent == nil {
+		parent = tmpl
+		parentKey = key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("could not create certificate. %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("could not parse certificate. %v", err)
+	}
+
+	return cert
+}
+
+// newTestSigningInformation creates signing information with a throwaway WWDR CA and pass certificate, as the
+// certificates in the test folder have expired.
+func newTestSigningInformation(t *testing.T) *SigningInformation {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("could not generate key. %v", err)
+	}
+
+	ca := newTestCertificate(t, "Test WWDR CA", caKey, nil, nil)
+	return &SigningInformation{
+		signingCert:     newTestCertificate(t, "Pass Type ID: pass.test", key, ca, caKey),
+		appleWWDRCACert: ca,
+		privateKey:      key,
+	}
+}
+
 func TestSigner_LoadSigningInformationFromFiles(t *testing.T) {
 	signingInfo, err := LoadSigningInformationFromFiles(filepath.Join("test", "passbook", "passkit.p12"), "password", filepath.Join("test", "passbook", "ca.pem"))
 	if err != nil {
-		t.Errorf("could not load signing info. %v", err)
+		t.Fatalf("could not load signing info. %v", err)
 	}
 
-	_, err = signManifestFile(nil, signingInfo)
+	_, err = signManifestFile(nil, signingInfo, newSignerOptions(nil))
 	if err == nil {
 		t.Errorf("should fail")
 	}
@@ -22,7 +93,7 @@ func TestSigner_LoadSigningInformationFromFiles(t *testing.T) {
 		t.Errorf("could not load pass json file. %v", err)
 	}
 
-	_, err = signManifestFile(passJson, signingInfo)
+	_, err = signManifestFile(passJson, signingInfo, newSignerOptions(nil))
 	if err != nil {
 		t.Errorf("could not sign manifest. %v", err)
 	}
@@ -46,3 +117,85 @@ func TestSigner_ValidCerts(t *testing.T) {
 		t.Errorf("should fail")
 	}
 }
+
+func TestSignManifestFile_Attributes(t *testing.T) {
+	manifest := []byte(`{"pass.json":"abc"}`)
+	before := time.Now().UTC().Add(-time.Second)
+
+	signature, err := signManifestFile(manifest, newTestSigningInformation(t), newSignerOptions(nil))
+	if err != nil {
+		t.Fatalf("could not sign manifest. %v", err)
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		t.Fatalf("could not parse signature. %v", err)
+	}
+
+	if len(p7.Content) != 0 {
+		t.Errorf("Signature should be detached")
+	}
+
+	if len(p7.Signers) != 1 {
+		t.Fatalf("Signature should have one signer, got %d", len(p7.Signers))
+	}
+
+	counts := make(map[string]int)
+	for _, attr := range p7.Signers[0].AuthenticatedAttributes {
+		counts[attr.Type.String()]++
+	}
+
+	for _, oid := range []asn1.ObjectIdentifier{pkcs7.OIDAttributeContentType, pkcs7.OIDAttributeMessageDigest, pkcs7.OIDAttributeSigningTime} {
+		if counts[oid.String()] != 1 {
+			t.Errorf("Signature should have exactly one %v attribute, got %d", oid, counts[oid.String()])
+		}
+	}
+
+	if len(counts) != 3 {
+		t.Errorf("Signature should only have the contentType, messageDigest and signingTime attributes, got %v", counts)
+	}
+
+	var signingTime time.Time
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeSigningTime, &signingTime); err != nil {
+		t.Fatalf("could not read signingTime. %v", err)
+	}
+
+	if signingTime.Before(before) || signingTime.After(time.Now().Add(time.Second)) {
+		t.Errorf("signingTime should be the current time, got %v", signingTime)
+	}
+
+	var contentType asn1.ObjectIdentifier
+	if err := p7.UnmarshalSignedAttribute(pkcs7.OIDAttributeContentType, &contentType); err != nil || !contentType.Equal(pkcs7.OIDData) {
+		t.Errorf("contentType should be data, got %v. %v", contentType, err)
+	}
+
+	p7.Content = manifest
+	if err := p7.Verify(); err != nil {
+		t.Errorf("Signature should match the manifest. %v", err)
+	}
+}
+
+func TestSignManifestFile_CustomAttributes(t *testing.T) {
+	oid := asn1.ObjectIdentifier{1, 2, 3, 4}
+	opts := newSignerOptions([]SignerOption{WithSignedAttributes(SignedAttribute{Type: oid, Value: "custom"})})
+
+	signature, err := signManifestFile([]byte("{}"), newTestSigningInformation(t), opts)
+	if err != nil {
+		t.Fatalf("could not sign manifest. %v", err)
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		t.Fatalf("could not parse signature. %v", err)
+	}
+
+	var value string
+	if err := p7.UnmarshalSignedAttribute(oid, &value); err != nil || value != "custom" {
+		t.Errorf("Signature should have the custom attribute, got %q. %v", value, err)
+	}
+
+	opts = newSignerOptions([]SignerOption{WithSignedAttributes(SignedAttribute{Type: pkcs7.OIDAttributeSigningTime, Value: time.Now()})})
+	if _, err := signManifestFile([]byte("{}"), newTestSigningInformation(t), opts); err == nil {
+		t.Errorf("Overriding the signingTime attribute should fail")
+	}
+}
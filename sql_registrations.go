@@ -0,0 +1,220 @@
+package passkit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RegistrationStoreSchema is the DDL of the tables used by SQLRegistrationStore, for SQLite and PostgreSQL. It can be
+// added to the migrations of an application instead of calling Migrate.
+var RegistrationStoreSchema = []string{
+	`CREATE TABLE IF NOT EXISTS passkit_devices (
+	device_library_identifier VARCHAR(255) NOT NULL PRIMARY KEY,
+	push_token VARCHAR(255) NOT NULL
+)`,
+	`CREATE TABLE IF NOT EXISTS passkit_registrations (
+	device_library_identifier VARCHAR(255) NOT NULL,
+	pass_type_identifier VARCHAR(255) NOT NULL,
+	serial_number VARCHAR(255) NOT NULL,
+	PRIMARY KEY (device_library_identifier, pass_type_identifier, serial_number)
+)`,
+	`CREATE INDEX IF NOT EXISTS passkit_registrations_pass ON passkit_registrations (pass_type_identifier, serial_number)`,
+	`CREATE TABLE IF NOT EXISTS passkit_pass_updates (
+	pass_type_identifier VARCHAR(255) NOT NULL,
+	serial_number VARCHAR(255) NOT NULL,
+	updated_tag BIGINT NOT NULL,
+	PRIMARY KEY (pass_type_identifier, serial_number)
+)`,
+}
+
+// SQLRegistrationOption configures a SQLRegistrationStore.
+type SQLRegistrationOption func(s *SQLRegistrationStore)
+
+// WithDollarPlaceholders writes the query parameters as $1, $2..., as required by PostgreSQL drivers, instead of ?.
+func WithDollarPlaceholders() SQLRegistrationOption {
+	return func(s *SQLRegistrationStore) {
+		s.dollarPlaceholders = true
+	}
+}
+
+// SQLRegistrationStore is a RegistrationStore that keeps the registrations in a database/sql database, in the
+// tables of RegistrationStoreSchema. The queries use INSERT ... ON CONFLICT, supported by SQLite and PostgreSQL.
+type SQLRegistrationStore struct {
+	db                 *sql.DB
+	dollarPlaceholders bool
+}
+
+func NewSQLRegistrationStore(db *sql.DB, opts ...SQLRegistrationOption) *SQLRegistrationStore {
+	s := &SQLRegistrationStore{db: db}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Migrate creates the tables of the store if they don't exist.
+func (s *SQLRegistrationStore) Migrate(ctx context.Context) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range RegistrationStoreSchema {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("could not create registration tables: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLRegistrationStore) Register(ctx context.Context, deviceLibraryIdentifier, pushToken, passTypeIdentifier, serialNumber string) (bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, s.query(`INSERT INTO passkit_devices (device_library_identifier, push_token) VALUES (?, ?)
+ON CONFLICT (device_library_identifier) DO UPDATE SET push_token = excluded.push_token`), deviceLibraryIdentifier, pushToken)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, s.query(`INSERT INTO passkit_registrations (device_library_identifier, pass_type_identifier, serial_number) VALUES (?, ?, ?)
+ON CONFLICT DO NOTHING`), deviceLibraryIdentifier, passTypeIdentifier, serialNumber)
+	if err != nil {
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n > 0, tx.Commit()
+}
+
+func (s *SQLRegistrationStore) Unregister(ctx context.Context, deviceLibraryIdentifier, passTypeIdentifier, serialNumber string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, s.query(`DELETE FROM passkit_registrations
+WHERE device_library_identifier = ? AND pass_type_identifier = ? AND serial_number = ?`), deviceLibraryIdentifier, passTypeIdentifier, serialNumber)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, s.query(`DELETE FROM passkit_devices WHERE device_library_identifier = ?
+AND NOT EXISTS (SELECT 1 FROM passkit_registrations r WHERE r.device_library_identifier = passkit_devices.device_library_identifier)`), deviceLibraryIdentifier)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLRegistrationStore) Registrations(ctx context.Context, passTypeIdentifier, serialNumber string) ([]Registration, error) {
+	rows, err := s.db.QueryContext(ctx, s.query(`SELECT d.device_library_identifier, d.push_token
+FROM passkit_registrations r JOIN passkit_devices d ON d.device_library_identifier = r.device_library_identifier
+WHERE r.pass_type_identifier = ? AND r.serial_number = ?
+ORDER BY d.device_library_identifier`), passTypeIdentifier, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var registrations []Registration
+	for rows.Next() {
+		var r Registration
+		if err := rows.Scan(&r.DeviceLibraryIdentifier, &r.PushToken); err != nil {
+			return nil, err
+		}
+		registrations = append(registrations, r)
+	}
+
+	return registrations, rows.Err()
+}
+
+func (s *SQLRegistrationStore) UpdatedSerials(ctx context.Context, deviceLibraryIdentifier, passTypeIdentifier, passesUpdatedSince string) ([]string, string, error) {
+	since, hasTag := parseUpdateTag(passesUpdatedSince)
+	if !hasTag {
+		since = -1
+	}
+
+	rows, err := s.db.QueryContext(ctx, s.query(`SELECT r.serial_number, COALESCE(u.updated_tag, 0)
+FROM passkit_registrations r LEFT JOIN passkit_pass_updates u
+ON u.pass_type_identifier = r.pass_type_identifier AND u.serial_number = r.serial_number
+WHERE r.device_library_identifier = ? AND r.pass_type_identifier = ? AND COALESCE(u.updated_tag, 0) > ?
+ORDER BY r.serial_number`), deviceLibraryIdentifier, passTypeIdentifier, since)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	var serials []string
+	last := max(since, 0)
+	for rows.Next() {
+		var serial string
+		var updated int64
+		if err := rows.Scan(&serial, &updated); err != nil {
+			return nil, "", err
+		}
+
+		serials = append(serials, serial)
+		last = max(last, updated)
+	}
+
+	return serials, strconv.FormatInt(last, 10), rows.Err()
+}
+
+func (s *SQLRegistrationStore) PassUpdated(ctx context.Context, passTypeIdentifier, serialNumber string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var previous int64
+	err = tx.QueryRowContext(ctx, s.query(`SELECT updated_tag FROM passkit_pass_updates
+WHERE pass_type_identifier = ? AND serial_number = ?`), passTypeIdentifier, serialNumber).Scan(&previous)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, s.query(`INSERT INTO passkit_pass_updates (pass_type_identifier, serial_number, updated_tag) VALUES (?, ?, ?)
+ON CONFLICT (pass_type_identifier, serial_number) DO UPDATE SET updated_tag = excluded.updated_tag`), passTypeIdentifier, serialNumber, newUpdateTag(previous))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// query rewrites the ? placeholders of q for the database.
+func (s *SQLRegistrationStore) query(q string) string {
+	if !s.dollarPlaceholders {
+		return q
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,254 @@
+package passkit
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+const (
+	timestampQueryContentType = "application/timestamp-query"
+	timestampReplyContentType = "application/timestamp-reply"
+)
+
+var (
+	oidTimeStampToken = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 14}
+	oidTSTInfo        = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 1, 4}
+)
+
+// TimestampAuthority issues RFC 3161 timestamp tokens.
+type TimestampAuthority interface {
+	// Timestamp returns a DER encoded TimeStampToken for digest, the hash of the timestamped data computed with h.
+	Timestamp(digest []byte, h crypto.Hash) ([]byte, error)
+}
+
+// WithTimestampAuthority timestamps the manifest signature with tsa, using a SHA-256 hash of the signature value.
+// The timestamp token is added to the unsigned attributes of the signature, so the time of signing can be proven
+// after the signing certificate expires.
+func WithTimestampAuthority(tsa TimestampAuthority) SignerOption {
+	return func(o *signerOptions) {
+		o.timestampAuthority = tsa
+	}
+}
+
+// HTTPTimestampAuthority requests timestamp tokens from an RFC 3161 timestamp server over HTTP.
+type HTTPTimestampAuthority struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPTimestampAuthority creates a TimestampAuthority for the timestamp server at url, using a client with a 30
+// second timeout.
+func NewHTTPTimestampAuthority(url string) *HTTPTimestampAuthority {
+	return &HTTPTimestampAuthority{URL: url, Client: &http.Client{Timeout: 30 * time.Second}}
+}
+
+func (a *HTTPTimestampAuthority) Timestamp(digest []byte, h crypto.Hash) ([]byte, error) {
+	oid, ok := digestAlgorithmOIDs[h]
+	if !ok {
+		return nil, fmt.Errorf("unsupported timestamp hash algorithm %v", h)
+	}
+
+	nonce, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := asn1.Marshal(timeStampReq{
+		Version:        1,
+		MessageImprint: messageImprint{HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid}, HashedMessage: digest},
+		Nonce:          nonce,
+		CertReq:        true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	client := a.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Post(a.URL, timestampQueryContentType, bytes.NewReader(req))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("timestamp server returned status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tsResp timeStampResp
+	if _, err := asn1.Unmarshal(body, &tsResp); err != nil {
+		return nil, fmt.Errorf("invalid timestamp response: %w", err)
+	}
+
+	// 0 is granted, 1 is granted with modifications
+	if tsResp.Status.Status > 1 {
+		return nil, fmt.Errorf("timestamp request rejected with status %d: %v", tsResp.Status.Status, tsResp.Status.StatusString)
+	}
+
+	token := tsResp.TimeStampToken.FullBytes
+	info, err := parseTimestampToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(info.MessageImprint.HashedMessage, digest) {
+		return nil, errors.New("timestamp token is for a different digest")
+	}
+
+	if info.Nonce == nil || info.Nonce.Cmp(nonce) != 0 {
+		return nil, errors.New("timestamp token nonce does not match the request")
+	}
+
+	return token, nil
+}
+
+// SignatureTimestamp returns the time of the RFC 3161 timestamp of a pass or order signature, or nil if the
+// signature is not timestamped. The timestamp token signature is checked, but not the certificate chain of the
+// timestamp authority.
+func SignatureTimestamp(signature []byte) (*time.Time, error) {
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(p7.Signers) == 0 {
+		return nil, errors.New("signature has no signers")
+	}
+
+	signer := p7.Signers[0]
+	for _, attr := range signer.UnauthenticatedAttributes {
+		if !attr.Type.Equal(oidTimeStampToken) {
+			continue
+		}
+
+		info, err := parseTimestampToken(attr.Value.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		h, ok := hashForDigestOID(info.MessageImprint.HashAlgorithm.Algorithm)
+		if !ok {
+			return nil, fmt.Errorf("unsupported timestamp hash algorithm %v", info.MessageImprint.HashAlgorithm.Algorithm)
+		}
+
+		hh := h.New()
+		hh.Write(signer.EncryptedDigest)
+		if !bytes.Equal(hh.Sum(nil), info.MessageImprint.HashedMessage) {
+			return nil, errors.New("timestamp token is for a different signature")
+		}
+
+		return &info.GenTime, nil
+	}
+
+	return nil, nil
+}
+
+// timestampSignature adds a timestamp token for the signature value of every signer of s.
+func timestampSignature(s *pkcs7.SignedData, tsa TimestampAuthority, h crypto.Hash) error {
+	signers := s.GetSignedData().SignerInfos
+	for idx := range signers {
+		hh := h.New()
+		hh.Write(signers[idx].EncryptedDigest)
+
+		token, err := tsa.Timestamp(hh.Sum(nil), h)
+		if err != nil {
+			return fmt.Errorf("could not timestamp signature: %w", err)
+		}
+
+		attr := pkcs7.Attribute{Type: oidTimeStampToken, Value: asn1.RawValue{FullBytes: token}}
+		if err := signers[idx].SetUnauthenticatedAttributes([]pkcs7.Attribute{attr}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseTimestampToken checks the signature of a TimeStampToken and returns its TSTInfo.
+func parseTimestampToken(token []byte) (*tstInfo, error) {
+	p7, err := pkcs7.Parse(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timestamp token: %w", err)
+	}
+
+	if err := p7.Verify(); err != nil {
+		return nil, fmt.Errorf("invalid timestamp token signature: %w", err)
+	}
+
+	var info tstInfo
+	if _, err := asn1.Unmarshal(p7.Content, &info); err != nil {
+		return nil, fmt.Errorf("invalid timestamp token info: %w", err)
+	}
+
+	return &info, nil
+}
+
+func hashForDigestOID(oid asn1.ObjectIdentifier) (crypto.Hash, bool) {
+	for h, o := range digestAlgorithmOIDs {
+		if o.Equal(oid) {
+			return h, true
+		}
+	}
+
+	return 0, false
+}
+
+type messageImprint struct {
+	HashAlgorithm pkix.AlgorithmIdentifier
+	HashedMessage []byte
+}
+
+type timeStampReq struct {
+	Version        int
+	MessageImprint messageImprint
+	ReqPolicy      asn1.ObjectIdentifier `asn1:"optional"`
+	Nonce          *big.Int              `asn1:"optional"`
+	CertReq        bool                  `asn1:"optional"`
+}
+
+type pkiStatusInfo struct {
+	Status       int
+	StatusString []string       `asn1:"optional,utf8"`
+	FailInfo     asn1.BitString `asn1:"optional"`
+}
+
+type timeStampResp struct {
+	Status         pkiStatusInfo
+	TimeStampToken asn1.RawValue `asn1:"optional"`
+}
+
+type tstAccuracy struct {
+	Seconds int `asn1:"optional"`
+	Millis  int `asn1:"optional,tag:0"`
+	Micros  int `asn1:"optional,tag:1"`
+}
+
+type tstInfo struct {
+	Version        int
+	Policy         asn1.ObjectIdentifier
+	MessageImprint messageImprint
+	SerialNumber   *big.Int
+	GenTime        time.Time   `asn1:"generalized"`
+	Accuracy       tstAccuracy `asn1:"optional"`
+	Ordering       bool        `asn1:"optional"`
+	Nonce          *big.Int    `asn1:"optional"`
+}
@@ -0,0 +1,150 @@
+package passkit
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// localTimestampAuthority is an in-process RFC 3161 timestamp authority with a self-signed certificate. It can be
+// used directly as a TimestampAuthority, or served over HTTP, as an http.Handler, to test HTTPTimestampAuthority.
+type localTimestampAuthority struct {
+	// Now returns the time written to the tokens, time.Now if nil
+	Now func() time.Time
+
+	cert   *x509.Certificate
+	key    crypto.Signer
+	mu     sync.Mutex
+	serial int64
+}
+
+// localTSAPolicy is the policy of the tokens issued by localTimestampAuthority, from the OID arc reserved for
+// examples.
+var localTSAPolicy = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 32473, 1}
+
+// newlocalTimestampAuthority creates a localTimestampAuthority with a new self-signed certificate.
+func newlocalTimestampAuthority() (*localTimestampAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "passkit local timestamp authority"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localTimestampAuthority{cert: cert, key: key}, nil
+}
+
+// Certificate returns the certificate the tokens are signed with.
+func (a *localTimestampAuthority) Certificate() *x509.Certificate {
+	return a.cert
+}
+
+func (a *localTimestampAuthority) Timestamp(digest []byte, h crypto.Hash) ([]byte, error) {
+	oid, ok := digestAlgorithmOIDs[h]
+	if !ok {
+		return nil, fmt.Errorf("unsupported timestamp hash algorithm %v", h)
+	}
+
+	return a.issue(messageImprint{HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oid}, HashedMessage: digest}, nil)
+}
+
+func (a *localTimestampAuthority) issue(imprint messageImprint, nonce *big.Int) ([]byte, error) {
+	now := time.Now
+	if a.Now != nil {
+		now = a.Now
+	}
+
+	a.mu.Lock()
+	a.serial++
+	serial := a.serial
+	a.mu.Unlock()
+
+	info, err := asn1.Marshal(tstInfo{
+		Version:        1,
+		Policy:         localTSAPolicy,
+		MessageImprint: imprint,
+		SerialNumber:   big.NewInt(serial),
+		GenTime:        now().UTC().Truncate(time.Second),
+		Nonce:          nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := pkcs7.NewSignedData(info)
+	if err != nil {
+		return nil, err
+	}
+
+	s.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	s.GetSignedData().ContentInfo.ContentType = oidTSTInfo
+	if err := s.AddSigner(a.cert, a.key, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, err
+	}
+
+	return s.Finish()
+}
+
+// ServeHTTP answers RFC 3161 timestamp queries.
+func (a *localTimestampAuthority) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != timestampQueryContentType {
+		http.Error(w, "expected a timestamp query", http.StatusBadRequest)
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var resp timeStampResp
+	var req timeStampReq
+	if _, err := asn1.Unmarshal(body, &req); err != nil {
+		// 2 is rejection
+		resp.Status = pkiStatusInfo{Status: 2, StatusString: []string{err.Error()}}
+	} else if token, err := a.issue(req.MessageImprint, req.Nonce); err != nil {
+		resp.Status = pkiStatusInfo{Status: 2, StatusString: []string{err.Error()}}
+	} else {
+		resp.TimeStampToken = asn1.RawValue{FullBytes: token}
+	}
+
+	b, err := asn1.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", timestampReplyContentType)
+	_, _ = w.Write(b)
+}
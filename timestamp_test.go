@@ -0,0 +1,102 @@
+package passkit
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type failingTimestampAuthority struct{}
+
+func (failingTimestampAuthority) Timestamp([]byte, crypto.Hash) ([]byte, error) {
+	return nil, errors.New("unavailable")
+}
+
+func newTestTimestampAuthority(t *testing.T) *localTimestampAuthority {
+	t.Helper()
+
+	tsa, err := newlocalTimestampAuthority()
+	if err != nil {
+		t.Fatalf("could not create timestamp authority. %v", err)
+	}
+
+	return tsa
+}
+
+func TestSignManifestFile_Timestamp(t *testing.T) {
+	tsa := newTestTimestampAuthority(t)
+	stamp := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	tsa.Now = func() time.Time { return stamp }
+
+	opts := newSignerOptions([]SignerOption{WithTimestampAuthority(tsa)})
+	signature, err := signManifestFile([]byte("{}"), newTestSigningInformation(t), opts)
+	if err != nil {
+		t.Fatalf("could not sign manifest. %v", err)
+	}
+
+	ts, err := SignatureTimestamp(signature)
+	if err != nil {
+		t.Fatalf("could not read timestamp. %v", err)
+	}
+
+	if ts == nil || !ts.Equal(stamp) {
+		t.Errorf("Signature should be timestamped at %v, got %v", stamp, ts)
+	}
+}
+
+func TestSignManifestFile_NoTimestamp(t *testing.T) {
+	signature, err := signManifestFile([]byte("{}"), newTestSigningInformation(t), newSignerOptions(nil))
+	if err != nil {
+		t.Fatalf("could not sign manifest. %v", err)
+	}
+
+	if ts, err := SignatureTimestamp(signature); err != nil || ts != nil {
+		t.Errorf("Signature should not be timestamped, got %v. %v", ts, err)
+	}
+}
+
+func TestSignManifestFile_TimestampFailure(t *testing.T) {
+	opts := newSignerOptions([]SignerOption{WithTimestampAuthority(failingTimestampAuthority{})})
+	if _, err := signManifestFile([]byte("{}"), newTestSigningInformation(t), opts); err == nil {
+		t.Errorf("Signing should fail when the timestamp can't be obtained")
+	}
+}
+
+func TestHTTPTimestampAuthority(t *testing.T) {
+	srv := httptest.NewServer(newTestTimestampAuthority(t))
+	defer srv.Close()
+
+	tsa := NewHTTPTimestampAuthority(srv.URL)
+	digest := sha256.Sum256([]byte("signature"))
+
+	token, err := tsa.Timestamp(digest[:], crypto.SHA256)
+	if err != nil {
+		t.Fatalf("could not get timestamp. %v", err)
+	}
+
+	info, err := parseTimestampToken(token)
+	if err != nil {
+		t.Fatalf("could not parse token. %v", err)
+	}
+
+	if time.Since(info.GenTime) > time.Minute {
+		t.Errorf("Timestamp should be the current time, got %v", info.GenTime)
+	}
+
+	signer := NewMemoryBasedSigner(WithTimestampAuthority(tsa))
+	sig, err := signer.SignManifestFile([]byte("{}"), newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign manifest. %v", err)
+	}
+
+	if ts, err := SignatureTimestamp(sig); err != nil || ts == nil {
+		t.Errorf("Signature should be timestamped. %v", err)
+	}
+
+	if _, err := NewHTTPTimestampAuthority(srv.URL+"/missing").Timestamp(digest[:], crypto.MD5); err == nil {
+		t.Errorf("Timestamping with MD5 should fail")
+	}
+}
@@ -0,0 +1,73 @@
+package passkit
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+const (
+	authenticationTokenBytes = 20
+	minTokenSecretLen        = 32
+)
+
+// GenerateAuthenticationToken returns a random authentication token for a pass, with 160 bits of entropy from
+// crypto/rand. The token has to be stored with the pass, to check the requests of the devices.
+func GenerateAuthenticationToken() (string, error) {
+	b := make([]byte, authenticationTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// AuthenticationTokenSecrets derives the authentication tokens of passes from their pass type identifier and
+// serial number with HMAC-SHA256, so they don't need to be stored. Tokens are created with the current secret and
+// accepted with any of the secrets, so secrets can be rotated without reissuing every pass at once.
+type AuthenticationTokenSecrets struct {
+	secrets [][]byte
+}
+
+// NewAuthenticationTokenSecrets creates AuthenticationTokenSecrets that create tokens with current, and also accept
+// the tokens created with the previous secrets. Secrets must be at least 32 bytes long.
+func NewAuthenticationTokenSecrets(current []byte, previous ...[]byte) (*AuthenticationTokenSecrets, error) {
+	secrets := append([][]byte{current}, previous...)
+	for _, secret := range secrets {
+		if len(secret) < minTokenSecretLen {
+			return nil, errors.New("authentication token secrets must be at least 32 bytes long")
+		}
+	}
+
+	return &AuthenticationTokenSecrets{secrets: secrets}, nil
+}
+
+// Token returns the authentication token of a pass, derived with the current secret.
+func (s *AuthenticationTokenSecrets) Token(passTypeIdentifier, serialNumber string) string {
+	return deriveAuthenticationToken(s.secrets[0], passTypeIdentifier, serialNumber)
+}
+
+// Verify reports whether token is the authentication token of the pass for any of the secrets. Every secret is
+// checked in constant time, so the time taken doesn't tell which secret matched.
+func (s *AuthenticationTokenSecrets) Verify(passTypeIdentifier, serialNumber, token string) bool {
+	valid := 0
+	for _, secret := range s.secrets {
+		expected := deriveAuthenticationToken(secret, passTypeIdentifier, serialNumber)
+		valid |= subtle.ConstantTimeCompare([]byte(token), []byte(expected))
+	}
+
+	return valid == 1
+}
+
+func deriveAuthenticationToken(secret []byte, passTypeIdentifier, serialNumber string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(passTypeIdentifier))
+	// The separator keeps different identifier and serial number splits from giving the same token
+	mac.Write([]byte{0})
+	mac.Write([]byte(serialNumber))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
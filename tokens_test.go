@@ -0,0 +1,92 @@
+package passkit
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+)
+
+func TestGenerateAuthenticationToken(t *testing.T) {
+	a, err := GenerateAuthenticationToken()
+	if err != nil {
+		t.Fatalf("could not generate token. %v", err)
+	}
+
+	b, err := GenerateAuthenticationToken()
+	if err != nil {
+		t.Fatalf("could not generate token. %v", err)
+	}
+
+	if len(a) < expectedAuthTokenLen || a == b {
+		t.Errorf("tokens should be long enough and unique, got %q and %q", a, b)
+	}
+
+	pass := getBasicPass()
+	pass.WebServiceURL = "https://example.com/passes"
+	pass.AuthenticationToken = a
+	if !pass.IsValid() {
+		t.Errorf("a generated token should be valid, got %v", pass.GetValidationErrors())
+	}
+}
+
+func TestAuthenticationTokenSecrets(t *testing.T) {
+	oldSecret := bytes.Repeat([]byte("o"), 32)
+	newSecret := bytes.Repeat([]byte("n"), 32)
+
+	if _, err := NewAuthenticationTokenSecrets([]byte("short")); err == nil {
+		t.Errorf("short secrets should be rejected")
+	}
+
+	old, err := NewAuthenticationTokenSecrets(oldSecret)
+	if err != nil {
+		t.Fatalf("could not create secrets. %v", err)
+	}
+
+	rotated, err := NewAuthenticationTokenSecrets(newSecret, oldSecret)
+	if err != nil {
+		t.Fatalf("could not create secrets. %v", err)
+	}
+
+	oldToken := old.Token("pass.test", "1")
+	if oldToken != old.Token("pass.test", "1") || len(oldToken) < expectedAuthTokenLen {
+		t.Errorf("tokens should be stable and long enough, got %q", oldToken)
+	}
+
+	newToken := rotated.Token("pass.test", "1")
+	if newToken == oldToken {
+		t.Errorf("tokens should be created with the current secret")
+	}
+
+	if !rotated.Verify("pass.test", "1", oldToken) || !rotated.Verify("pass.test", "1", newToken) {
+		t.Errorf("tokens of every secret should be accepted")
+	}
+
+	if rotated.Verify("pass.test", "2", newToken) || old.Verify("pass.test", "1", newToken) {
+		t.Errorf("tokens should only be accepted for their pass and secrets")
+	}
+
+	if old.Token("pass.test", "1") == old.Token("pass.test1", "") {
+		t.Errorf("identifier and serial number splits should give different tokens")
+	}
+}
+
+func TestWebService_AuthenticationTokenSecrets(t *testing.T) {
+	secrets, err := NewAuthenticationTokenSecrets(bytes.Repeat([]byte("s"), 32))
+	if err != nil {
+		t.Fatalf("could not create secrets. %v", err)
+	}
+
+	s := newTestWebService(WithAuthenticationTokenSecrets(secrets))
+
+	if w := doWebServiceRequest(s, http.MethodGet, "/v1/passes/pass.test/1", "token1234567890ab", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("the stored token should not be accepted, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodGet, "/v1/passes/pass.test/1", secrets.Token("pass.test", "2"), ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("the token of another pass should not be accepted, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodGet, "/v1/passes/pass.test/1", secrets.Token("pass.test", "1"), ""); w.Code != http.StatusOK {
+		t.Errorf("the derived token should be accepted, got %d", w.Code)
+	}
+}
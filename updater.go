@@ -0,0 +1,337 @@
+package passkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+const defaultPushDebounce = 2 * time.Second
+
+// PassStore keeps the passes updated by a PassUpdater, and serves their latest signed version to the web service.
+type PassStore interface {
+	PassProvider
+	// LoadPass returns the current version of a pass and the template it is signed with, or ErrPassNotFound.
+	LoadPass(ctx context.Context, passTypeIdentifier, serialNumber string) (*Pass, PassTemplate, error)
+	// SavePass stores a new version of a pass, and the archive Pass returns for it from then on.
+	SavePass(ctx context.Context, p *Pass, served *ServedPass) error
+}
+
+// MemoryPassStore is a PassStore that keeps the passes in memory, for tests and development.
+type MemoryPassStore struct {
+	mu     sync.RWMutex
+	passes map[passKey]*memoryStoredPass
+}
+
+type memoryStoredPass struct {
+	pass     *Pass
+	template PassTemplate
+	served   *ServedPass
+}
+
+func NewMemoryPassStore() *MemoryPassStore {
+	return &MemoryPassStore{passes: make(map[passKey]*memoryStoredPass)}
+}
+
+// AddPass adds a pass to the store, with the template it is signed with and its signed archive. The pass needs an
+// authentication token of at least 16 characters, as the web service authenticates the devices with it.
+func (m *MemoryPassStore) AddPass(p *Pass, t PassTemplate, archive PassArchive) error {
+	if err := checkStoredToken(p, p.AuthenticationToken); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.passes[passKey{p.PassTypeIdentifier, p.SerialNumber}] = &memoryStoredPass{
+		pass:     p.Clone(),
+		template: t,
+		served:   &ServedPass{Archive: archive, AuthenticationToken: p.AuthenticationToken, LastModified: time.Now()},
+	}
+	return nil
+}
+
+func (m *MemoryPassStore) Pass(_ context.Context, passTypeIdentifier, serialNumber string) (*ServedPass, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.passes[passKey{passTypeIdentifier, serialNumber}]
+	if !ok {
+		return nil, ErrPassNotFound
+	}
+
+	return s.served, nil
+}
+
+func (m *MemoryPassStore) LoadPass(_ context.Context, passTypeIdentifier, serialNumber string) (*Pass, PassTemplate, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	s, ok := m.passes[passKey{passTypeIdentifier, serialNumber}]
+	if !ok {
+		return nil, nil, ErrPassNotFound
+	}
+
+	return s.pass.Clone(), s.template, nil
+}
+
+func (m *MemoryPassStore) SavePass(_ context.Context, p *Pass, served *ServedPass) error {
+	if err := checkStoredToken(p, served.AuthenticationToken); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	s, ok := m.passes[passKey{p.PassTypeIdentifier, p.SerialNumber}]
+	if !ok {
+		return ErrPassNotFound
+	}
+
+	s.pass = p.Clone()
+	s.served = served
+	return nil
+}
+
+// checkStoredToken rejects the passes the web service couldn't authenticate devices for.
+func checkStoredToken(p *Pass, token string) error {
+	if len(token) < expectedAuthTokenLen {
+		return fmt.Errorf("the authenticationToken of pass %s/%s needs to be at least %d characters long", p.PassTypeIdentifier, p.SerialNumber, expectedAuthTokenLen)
+	}
+
+	return nil
+}
+
+// PassUpdaterOption configures a PassUpdater.
+type PassUpdaterOption func(u *PassUpdater)
+
+// WithPushDebounce sets how long a PassUpdater waits after the last update of a pass before notifying the devices,
+// so a burst of updates is sent with a single notification. The default is two seconds.
+func WithPushDebounce(d time.Duration) PassUpdaterOption {
+	return func(u *PassUpdater) {
+		u.debounce = d
+	}
+}
+
+// WithPushErrorHandler sets the function called with the errors of the notifications sent in the background. By
+// default they are logged with slog.
+func WithPushErrorHandler(handler func(passTypeIdentifier, serialNumber string, err error)) PassUpdaterOption {
+	return func(u *PassUpdater) {
+		u.errorHandler = handler
+	}
+}
+
+// PassUpdater updates passes and notifies the devices holding them: it signs the new version of the pass, stores
+// it, gives it a new update tag in the registration store, and sends a push notification to the registered
+// devices. Devices whose push token is rejected as unregistered by APNs are unregistered from the pass.
+type PassUpdater struct {
+	passes        PassStore
+	signer        Signer
+	info          *SigningInformation
+	registrations RegistrationStore
+	notifier      PushNotifier
+	debounce      time.Duration
+	errorHandler  func(passTypeIdentifier, serialNumber string, err error)
+
+	mu    sync.Mutex
+	locks map[passKey]*passLock
+	// unpublished are the passes saved whose update could not be recorded in the registration store
+	unpublished map[passKey]bool
+	pending     map[passKey]*time.Timer
+	pushes      sync.WaitGroup
+}
+
+// NewPassUpdater creates a PassUpdater that stores the passes in passes, signs them with signer and info, and
+// notifies the devices in registrations with notifier.
+func NewPassUpdater(passes PassStore, signer Signer, info *SigningInformation, registrations RegistrationStore, notifier PushNotifier, opts ...PassUpdaterOption) *PassUpdater {
+	u := &PassUpdater{
+		passes:        passes,
+		signer:        signer,
+		info:          info,
+		registrations: registrations,
+		notifier:      notifier,
+		debounce:      defaultPushDebounce,
+		errorHandler: func(passTypeIdentifier, serialNumber string, err error) {
+			slog.Error("could not notify pass update", "passTypeIdentifier", passTypeIdentifier, "serialNumber", serialNumber, "error", err)
+		},
+		locks:       make(map[passKey]*passLock),
+		unpublished: make(map[passKey]bool),
+		pending:     make(map[passKey]*time.Timer),
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// UpdatePass applies mutate to a copy of the current version of a pass and publishes the result. Updates of the
+// same pass are serialized. If signing fails nothing is stored, and if mutate doesn't change the pass nothing is
+// signed and no notification is sent, so retrying an update is safe. The devices are notified once the pass goes
+// unchanged for the debounce interval. The returned diff lists the changes made by mutate.
+func (u *PassUpdater) UpdatePass(ctx context.Context, passTypeIdentifier, serialNumber string, mutate func(p *Pass)) (*PassDiff, error) {
+	key := passKey{passTypeIdentifier, serialNumber}
+	defer u.lockPass(key)()
+
+	current, template, err := u.passes.LoadPass(ctx, passTypeIdentifier, serialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	updated := current.Clone()
+	mutate(updated)
+
+	if updated.PassTypeIdentifier != passTypeIdentifier || updated.SerialNumber != serialNumber {
+		return nil, errors.New("the pass type identifier and serial number of an updated pass can't change")
+	}
+
+	diff, err := DiffPasses(current, updated)
+	if err != nil {
+		return nil, err
+	}
+
+	if diff.HasChanges() {
+		z, err := u.signer.CreateSignedAndZippedPassArchive(updated, template, u.info)
+		if err != nil {
+			return nil, err
+		}
+
+		served := &ServedPass{Archive: z, AuthenticationToken: updated.AuthenticationToken, LastModified: time.Now()}
+		if err := u.passes.SavePass(ctx, updated, served); err != nil {
+			return nil, err
+		}
+	} else if !u.isUnpublished(key) {
+		return diff, nil
+	}
+
+	if err := u.registrations.PassUpdated(ctx, passTypeIdentifier, serialNumber); err != nil {
+		u.setUnpublished(key, true)
+		return nil, fmt.Errorf("pass saved but the update could not be recorded: %w", err)
+	}
+
+	u.setUnpublished(key, false)
+	u.schedulePush(key)
+	return diff, nil
+}
+
+// Flush sends the pending notifications without waiting for the debounce interval, and waits until every
+// notification has been sent.
+func (u *PassUpdater) Flush() {
+	u.mu.Lock()
+	var keys []passKey
+	for key, timer := range u.pending {
+		if timer.Stop() {
+			keys = append(keys, key)
+			delete(u.pending, key)
+		}
+	}
+	u.mu.Unlock()
+
+	// The stopped timers leave their slot in u.pushes to these pushes
+	for _, key := range keys {
+		go u.push(key)
+	}
+
+	u.pushes.Wait()
+}
+
+// passLock serializes the updates of a pass. It is removed from PassUpdater.locks when no update holds or waits
+// for it.
+type passLock struct {
+	sync.Mutex
+	refs int
+}
+
+// lockPass locks the pass, and returns the function that unlocks it.
+func (u *PassUpdater) lockPass(key passKey) func() {
+	u.mu.Lock()
+	l := u.locks[key]
+	if l == nil {
+		l = &passLock{}
+		u.locks[key] = l
+	}
+	l.refs++
+	u.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		l.refs--
+		if l.refs == 0 {
+			delete(u.locks, key)
+		}
+	}
+}
+
+func (u *PassUpdater) isUnpublished(key passKey) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.unpublished[key]
+}
+
+func (u *PassUpdater) setUnpublished(key passKey, unpublished bool) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if unpublished {
+		u.unpublished[key] = true
+	} else {
+		delete(u.unpublished, key)
+	}
+}
+
+// schedulePush notifies the devices of the pass once the debounce interval passes without further updates of it.
+func (u *PassUpdater) schedulePush(key passKey) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	// A pending notification is postponed, keeping its slot in u.pushes
+	if timer := u.pending[key]; timer != nil && timer.Stop() {
+		timer.Reset(u.debounce)
+		return
+	}
+
+	u.pushes.Add(1)
+	var timer *time.Timer
+	timer = time.AfterFunc(u.debounce, func() {
+		u.mu.Lock()
+		// A later update may have scheduled another notification already
+		if u.pending[key] == timer {
+			delete(u.pending, key)
+		}
+		u.mu.Unlock()
+
+		u.push(key)
+	})
+	u.pending[key] = timer
+}
+
+// push notifies every device registered for the pass. It must be called with a slot in u.pushes.
+func (u *PassUpdater) push(key passKey) {
+	defer u.pushes.Done()
+
+	ctx := context.Background()
+	registrations, err := u.registrations.Registrations(ctx, key.passTypeIdentifier, key.serialNumber)
+	if err != nil {
+		u.errorHandler(key.passTypeIdentifier, key.serialNumber, err)
+		return
+	}
+
+	for _, r := range registrations {
+		err := u.notifier.Notify(ctx, key.passTypeIdentifier, r.PushToken)
+		if isUnregisteredPushToken(err) {
+			err = u.registrations.Unregister(ctx, r.DeviceLibraryIdentifier, key.passTypeIdentifier, key.serialNumber)
+		}
+
+		if err != nil {
+			u.errorHandler(key.passTypeIdentifier, key.serialNumber, err)
+		}
+	}
+}
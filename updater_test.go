@@ -0,0 +1,288 @@
+package passkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testNotifier struct {
+	mu            sync.Mutex
+	notifications []string
+	unregistered  map[string]bool
+}
+
+func (n *testNotifier) Notify(_ context.Context, passTypeIdentifier, pushToken string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.unregistered[pushToken] {
+		return &APNsError{StatusCode: 410, Reason: "Unregistered"}
+	}
+
+	n.notifications = append(n.notifications, passTypeIdentifier+"/"+pushToken)
+	return nil
+}
+
+func (n *testNotifier) sent() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]string(nil), n.notifications...)
+}
+
+type failingRegistrationStore struct {
+	RegistrationStore
+	fail bool
+}
+
+func (s *failingRegistrationStore) PassUpdated(ctx context.Context, passTypeIdentifier, serialNumber string) error {
+	if s.fail {
+		return errors.New("database down")
+	}
+
+	return s.RegistrationStore.PassUpdated(ctx, passTypeIdentifier, serialNumber)
+}
+
+func newTestPassUpdater(t *testing.T, registrations RegistrationStore, notifier PushNotifier) (*PassUpdater, *MemoryPassStore, *Pass) {
+	t.Helper()
+
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	info := newTestSigningInformation(t)
+	signer := NewMemoryBasedSigner()
+	z, err := signer.CreateSignedAndZippedPassArchive(&pass, template, info)
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	store := NewMemoryPassStore()
+	if err := store.AddPass(&pass, template, z); err != nil {
+		t.Fatalf("could not add pass. %v", err)
+	}
+
+	return NewPassUpdater(store, signer, info, registrations, notifier, WithPushDebounce(time.Hour)), store, &pass
+}
+
+func TestMemoryPassStore_AuthenticationToken(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryPassStore()
+	template := NewInMemoryPassTemplate()
+
+	pass := getBasicPass()
+	pass.AuthenticationToken = ""
+	if err := store.AddPass(&pass, template, PassArchive("archive")); err == nil {
+		t.Errorf("passes without authentication token should be rejected")
+	}
+
+	pass.AuthenticationToken = "short"
+	if err := store.AddPass(&pass, template, PassArchive("archive")); err == nil {
+		t.Errorf("passes with a short authentication token should be rejected")
+	}
+
+	if _, err := store.Pass(ctx, pass.PassTypeIdentifier, pass.SerialNumber); !errors.Is(err, ErrPassNotFound) {
+		t.Errorf("rejected passes should not be served, got %v", err)
+	}
+
+	pass.AuthenticationToken = "token1234567890ab"
+	if err := store.AddPass(&pass, template, PassArchive("archive")); err != nil {
+		t.Fatalf("could not add pass. %v", err)
+	}
+
+	if err := store.SavePass(ctx, &pass, &ServedPass{Archive: PassArchive("archive")}); err == nil {
+		t.Errorf("saving a pass without authentication token should be rejected")
+	}
+}
+
+func TestPassUpdater_UpdatePass(t *testing.T) {
+	ctx := context.Background()
+	registrations := NewMemoryRegistrationStore()
+	notifier := &testNotifier{}
+	u, store, pass := newTestPassUpdater(t, registrations, notifier)
+
+	if _, err := registrations.Register(ctx, "device1", "push1", pass.PassTypeIdentifier, pass.SerialNumber); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	_, tag, _ := registrations.UpdatedSerials(ctx, "device1", pass.PassTypeIdentifier, "")
+	before, _ := store.Pass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+
+	for _, description := range []string{"first", "second", "third"} {
+		diff, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, func(p *Pass) {
+			p.Description = description
+		})
+		if err != nil {
+			t.Fatalf("could not update pass. %v", err)
+		}
+
+		if !diff.HasChanges() {
+			t.Errorf("the update should have changes")
+		}
+	}
+
+	updated, _, _ := store.LoadPass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+	if updated.Description != "third" {
+		t.Errorf("the last update should be stored, got %q", updated.Description)
+	}
+
+	served, _ := store.Pass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+	if string(served.Archive) == string(before.Archive) {
+		t.Errorf("a new archive should be served")
+	}
+
+	serials, _, _ := registrations.UpdatedSerials(ctx, "device1", pass.PassTypeIdentifier, tag)
+	if len(serials) != 1 {
+		t.Errorf("the pass should have a new update tag, got %v", serials)
+	}
+
+	if len(notifier.sent()) != 0 {
+		t.Errorf("notifications should wait for the debounce interval")
+	}
+
+	u.Flush()
+	if sent := notifier.sent(); len(sent) != 1 || sent[0] != pass.PassTypeIdentifier+"/push1" {
+		t.Errorf("rapid updates should send a single notification, got %v", sent)
+	}
+}
+
+func TestPassUpdater_Debounce(t *testing.T) {
+	ctx := context.Background()
+	registrations := NewMemoryRegistrationStore()
+	notifier := &testNotifier{}
+	u, _, pass := newTestPassUpdater(t, registrations, notifier)
+	u.debounce = 100 * time.Millisecond
+
+	if _, err := registrations.Register(ctx, "device1", "push1", pass.PassTypeIdentifier, pass.SerialNumber); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	// The updates go on for longer than the debounce interval, but never pause for that long
+	for i := 0; i < 8; i++ {
+		if _, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, func(p *Pass) {
+			p.Description = fmt.Sprintf("update %d", i)
+		}); err != nil {
+			t.Fatalf("could not update pass. %v", err)
+		}
+		time.Sleep(30 * time.Millisecond)
+	}
+
+	if sent := notifier.sent(); len(sent) != 0 {
+		t.Errorf("notifications should wait until the updates stop, got %v", sent)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	u.Flush()
+	if sent := notifier.sent(); len(sent) != 1 {
+		t.Errorf("the burst of updates should send a single notification, got %v", sent)
+	}
+
+	if len(u.locks) != 0 {
+		t.Errorf("the locks of the passes should be released, got %d", len(u.locks))
+	}
+}
+
+func TestPassUpdater_NoChanges(t *testing.T) {
+	ctx := context.Background()
+	registrations := NewMemoryRegistrationStore()
+	notifier := &testNotifier{}
+	u, store, pass := newTestPassUpdater(t, registrations, notifier)
+
+	if _, err := registrations.Register(ctx, "device1", "push1", pass.PassTypeIdentifier, pass.SerialNumber); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	_, tag, _ := registrations.UpdatedSerials(ctx, "device1", pass.PassTypeIdentifier, "")
+	before, _ := store.Pass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+
+	diff, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, func(p *Pass) {
+		p.Description = pass.Description
+	})
+	if err != nil || diff.HasChanges() {
+		t.Fatalf("the update should have no changes, got %v, %v", diff, err)
+	}
+
+	after, _ := store.Pass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+	if after != before {
+		t.Errorf("the pass should not be signed again")
+	}
+
+	serials, _, _ := registrations.UpdatedSerials(ctx, "device1", pass.PassTypeIdentifier, tag)
+	u.Flush()
+	if len(serials) != 0 || len(notifier.sent()) != 0 {
+		t.Errorf("unchanged passes should not be published, got %v, %v", serials, notifier.sent())
+	}
+}
+
+func TestPassUpdater_InvalidUpdate(t *testing.T) {
+	ctx := context.Background()
+	u, store, pass := newTestPassUpdater(t, NewMemoryRegistrationStore(), &testNotifier{})
+
+	_, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, func(p *Pass) {
+		p.OrganizationName = ""
+	})
+	if err == nil {
+		t.Fatalf("an invalid pass should not be updated")
+	}
+
+	stored, _, _ := store.LoadPass(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+	if stored.OrganizationName != pass.OrganizationName {
+		t.Errorf("a failed update should not be stored")
+	}
+
+	if _, err := u.UpdatePass(ctx, "pass.unknown", "1", func(p *Pass) {}); !errors.Is(err, ErrPassNotFound) {
+		t.Errorf("expected ErrPassNotFound, got %v", err)
+	}
+}
+
+func TestPassUpdater_RetryUnpublished(t *testing.T) {
+	ctx := context.Background()
+	registrations := &failingRegistrationStore{RegistrationStore: NewMemoryRegistrationStore(), fail: true}
+	notifier := &testNotifier{}
+	u, _, pass := newTestPassUpdater(t, registrations, notifier)
+
+	if _, err := registrations.Register(ctx, "device1", "push1", pass.PassTypeIdentifier, pass.SerialNumber); err != nil {
+		t.Fatalf("could not register. %v", err)
+	}
+
+	mutate := func(p *Pass) { p.Description = "changed" }
+	if _, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, mutate); err == nil {
+		t.Fatalf("the update should fail when it can't be recorded")
+	}
+
+	registrations.fail = false
+	if _, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, mutate); err != nil {
+		t.Fatalf("could not retry update. %v", err)
+	}
+
+	u.Flush()
+	if len(notifier.sent()) != 1 {
+		t.Errorf("retrying the update should notify the devices, got %v", notifier.sent())
+	}
+}
+
+func TestPassUpdater_UnregistersStaleTokens(t *testing.T) {
+	ctx := context.Background()
+	registrations := NewMemoryRegistrationStore()
+	notifier := &testNotifier{unregistered: map[string]bool{"stale": true}}
+	u, _, pass := newTestPassUpdater(t, registrations, notifier)
+
+	for device, token := range map[string]string{"device1": "push1", "device2": "stale"} {
+		if _, err := registrations.Register(ctx, device, token, pass.PassTypeIdentifier, pass.SerialNumber); err != nil {
+			t.Fatalf("could not register. %v", err)
+		}
+	}
+
+	if _, err := u.UpdatePass(ctx, pass.PassTypeIdentifier, pass.SerialNumber, func(p *Pass) { p.Description = "changed" }); err != nil {
+		t.Fatalf("could not update pass. %v", err)
+	}
+
+	u.Flush()
+	r, _ := registrations.Registrations(ctx, pass.PassTypeIdentifier, pass.SerialNumber)
+	if len(r) != 1 || r[0].DeviceLibraryIdentifier != "device1" {
+		t.Errorf("the device with the stale token should be unregistered, got %v", r)
+	}
+}
@@ -0,0 +1,238 @@
+package passkit
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const passTypeIdentifierPrefix = "pass."
+
+type ValidationSeverity int
+
+const (
+	// SeverityError findings make the pass invalid. The signers refuse to sign passes with errors.
+	SeverityError ValidationSeverity = iota
+	// SeverityWarning findings are advisory. The pass can be signed, but may not look or work as expected on
+	// every device.
+	SeverityWarning
+)
+
+func (s ValidationSeverity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return fmt.Sprintf("ValidationSeverity(%d)", int(s))
+	}
+}
+
+// SeverityValidateable is implemented by the types that can report advisory findings besides errors.
+type SeverityValidateable interface {
+	Validateable
+	GetValidationFindings() ValidationFindings
+}
+
+// ValidationFinding is a single problem found while validating a pass.
+type ValidationFinding struct {
+	Severity ValidationSeverity
+	Message  string
+}
+
+func (f ValidationFinding) String() string {
+	return f.Severity.String() + ": " + f.Message
+}
+
+type ValidationFindings []ValidationFinding
+
+func newValidationFindings(errors, warnings []string) ValidationFindings {
+	var findings ValidationFindings
+	for _, e := range errors {
+		findings = append(findings, ValidationFinding{Severity: SeverityError, Message: e})
+	}
+	for _, w := range warnings {
+		findings = append(findings, ValidationFinding{Severity: SeverityWarning, Message: w})
+	}
+
+	return findings
+}
+
+// HasErrors reports whether any of the findings is an error.
+func (f ValidationFindings) HasErrors() bool {
+	return len(f.Errors()) > 0
+}
+
+// Errors returns the messages of the error findings.
+func (f ValidationFindings) Errors() []string {
+	return f.messages(SeverityError)
+}
+
+// Warnings returns the messages of the warning findings.
+func (f ValidationFindings) Warnings() []string {
+	return f.messages(SeverityWarning)
+}
+
+// Err returns a *ValidationError with the error findings, or nil if there are none.
+func (f ValidationFindings) Err() error {
+	if errs := f.Errors(); len(errs) > 0 {
+		return &ValidationError{Errors: errs}
+	}
+
+	return nil
+}
+
+func (f ValidationFindings) messages(severity ValidationSeverity) []string {
+	var messages []string
+	for _, finding := range f {
+		if finding.Severity == severity {
+			messages = append(messages, finding.Message)
+		}
+	}
+
+	return messages
+}
+
+var rgbColorRegexp = regexp.MustCompile(`^rgb\(\s*([0-9]{1,3})\s*,\s*([0-9]{1,3})\s*,\s*([0-9]{1,3})\s*\)$`)
+
+// validateRGBColor checks that color is a CSS-style RGB triple, like rgb(23, 187, 82). Empty colors are valid,
+// as all colors are optional.
+func validateRGBColor(name, color string) []string {
+	if color == "" {
+		return nil
+	}
+
+	m := rgbColorRegexp.FindStringSubmatch(color)
+	if m == nil {
+		return []string{fmt.Sprintf("Pass: %s %q is not a valid rgb(r, g, b) color", name, color)}
+	}
+
+	for _, c := range m[1:] {
+		if v, _ := strconv.Atoi(c); v > 255 {
+			return []string{fmt.Sprintf("Pass: %s %q has a component greater than 255", name, color)}
+		}
+	}
+
+	return nil
+}
+
+// validateURL checks that rawURL is an absolute URL using one of the given schemes. If no schemes are given any
+// scheme is accepted. Empty URLs are valid, as all URLs are optional.
+func validateURL(owner, name, rawURL string, schemes ...string) []string {
+	if rawURL == "" {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" {
+		return []string{fmt.Sprintf("%s: %s %q is not a valid absolute URL", owner, name, rawURL)}
+	}
+
+	if len(schemes) == 0 {
+		return nil
+	}
+
+	for _, s := range schemes {
+		if strings.EqualFold(u.Scheme, s) {
+			if (s == "http" || s == "https") && u.Host == "" {
+				return []string{fmt.Sprintf("%s: %s %q has no host", owner, name, rawURL)}
+			}
+			return nil
+		}
+	}
+
+	return []string{fmt.Sprintf("%s: %s %q must use one of the schemes %v", owner, name, rawURL, schemes)}
+}
+
+const earthRadiusMeters = 6371000
+
+// validateCoordinates checks that the latitude and longitude are within their valid ranges.
+func validateCoordinates(owner string, latitude, longitude float64) []string {
+	var validationErrors []string
+
+	if latitude < -90 || latitude > 90 || math.IsNaN(latitude) {
+		validationErrors = append(validationErrors, fmt.Sprintf("%s: The latitude %v must be between -90 and 90", owner, latitude))
+	}
+
+	if longitude < -180 || longitude > 180 || math.IsNaN(longitude) {
+		validationErrors = append(validationErrors, fmt.Sprintf("%s: The longitude %v must be between -180 and 180", owner, longitude))
+	}
+
+	return validationErrors
+}
+
+// distanceMeters returns the great-circle distance between two locations using the haversine formula.
+func distanceMeters(a, b Location) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Sqrt(h))
+}
+
+// bundleImageNames are the images a pass can contain, without the scale suffix and extension.
+var bundleImageNames = []string{"icon", "logo", "secondaryLogo", "thumbnail", "strip", "background", "footer",
+	"personalizationLogo", "artwork", "venueMap"}
+
+// ValidatePassBundle validates the pass, the optional personalization dictionary and the files of the template
+// they will be signed with. Besides the pass findings it warns about images that are missing a @3x version.
+func ValidatePassBundle(p *Pass, pz *Personalization, t PassTemplate) (ValidationFindings, error) {
+	files, err := t.GetAllFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return validatePassBundleFiles(p, pz, files), nil
+}
+
+func validatePassBundleFiles(p *Pass, pz *Personalization, files map[string][]byte) ValidationFindings {
+	findings := p.GetValidationFindings()
+
+	if pz != nil {
+		findings = append(findings, pz.GetValidationFindings()...)
+	}
+
+	return append(findings, newValidationFindings(posterArtworkErrors(p, files), missingRetinaHDImageWarnings(files))...)
+}
+
+// missingRetinaHDImageWarnings warns about every image, localized or not, that has a @1x or @2x version but no
+// @3x one.
+func missingRetinaHDImageWarnings(files map[string][]byte) []string {
+	var warnings []string
+	checked := make(map[string]bool)
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dir, file := path.Split(name)
+		base := strings.TrimSuffix(strings.TrimSuffix(file, ".png"), "@2x")
+		if !slices.Contains(bundleImageNames, base) || base+".png" != file && base+"@2x.png" != file {
+			continue
+		}
+
+		retinaHD := dir + base + "@3x.png"
+		if checked[retinaHD] {
+			continue
+		}
+		checked[retinaHD] = true
+
+		if _, ok := files[retinaHD]; !ok {
+			warnings = append(warnings, fmt.Sprintf("Template: %s is missing, the image will look blurry on high resolution screens", retinaHD))
+		}
+	}
+
+	return warnings
+}
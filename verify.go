@@ -0,0 +1,115 @@
+package passkit
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"go.mozilla.org/pkcs7"
+)
+
+// manifestHashesBySize are the manifest hash algorithms, by the length of their hex encoded hashes.
+var manifestHashesBySize = map[int]crypto.Hash{
+	40:  crypto.SHA1,
+	64:  crypto.SHA256,
+	96:  crypto.SHA384,
+	128: crypto.SHA512,
+}
+
+// VerifyPassArchive checks that every file of a signed .pkpass or .order archive is listed in the manifest with the
+// right hash, and that the signature matches the manifest. The certificates of the signature are not checked
+// against a trust store.
+func VerifyPassArchive(archive []byte) error {
+	files, err := ReadArchiveFiles(archive)
+	if err != nil {
+		return err
+	}
+
+	mfst, ok := files[manifestJsonFileName]
+	if !ok {
+		return fmt.Errorf("archive has no %s", manifestJsonFileName)
+	}
+
+	signature, ok := files[signatureFileName]
+	if !ok {
+		return fmt.Errorf("archive has no %s", signatureFileName)
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(mfst, &manifest); err != nil {
+		return fmt.Errorf("invalid %s: %w", manifestJsonFileName, err)
+	}
+
+	for name, data := range files {
+		if name == manifestJsonFileName || name == signatureFileName {
+			continue
+		}
+
+		expected, ok := manifest[name]
+		if !ok {
+			return fmt.Errorf("%s is not listed in the manifest", name)
+		}
+
+		h, ok := manifestHashesBySize[len(expected)]
+		if !ok {
+			return fmt.Errorf("unknown hash algorithm for %s in the manifest", name)
+		}
+
+		hh := h.New()
+		hh.Write(data)
+		if fmt.Sprintf("%x", hh.Sum(nil)) != expected {
+			return fmt.Errorf("the hash of %s does not match the manifest", name)
+		}
+	}
+
+	for name := range manifest {
+		if _, ok := files[name]; !ok {
+			return fmt.Errorf("%s is listed in the manifest but missing from the archive", name)
+		}
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %w", err)
+	}
+
+	p7.Content = mfst
+	if err := p7.Verify(); err != nil {
+		return fmt.Errorf("the signature does not match the manifest: %w", err)
+	}
+
+	return nil
+}
+
+// ReadArchiveFiles returns the contents of every file in a .pkpass, .order or .pkpasses archive, by name.
+func ReadArchiveFiles(archive []byte) (map[string][]byte, error) {
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(r.File))
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		files[f.Name] = b
+	}
+
+	return files, nil
+}
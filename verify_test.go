@@ -0,0 +1,57 @@
+package passkit
+
+import (
+	"testing"
+)
+
+func TestVerifyPassArchive(t *testing.T) {
+	pass := getBasicPass()
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes(BundleIcon, []byte("icon"))
+
+	z, err := NewMemoryBasedSigner().CreateSignedAndZippedPassArchive(&pass, template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign pass. %v", err)
+	}
+
+	if err := VerifyPassArchive(z); err != nil {
+		t.Errorf("Signed pass should be verified. %v", err)
+	}
+
+	files := readZip(t, z)
+	files[BundleIcon] = []byte("changed")
+	tampered, err := createZipArchive(files)
+	if err != nil {
+		t.Fatalf("could not zip pass. %v", err)
+	}
+
+	if err := VerifyPassArchive(tampered); err == nil {
+		t.Errorf("Pass with a changed file should not be verified")
+	}
+
+	files = readZip(t, z)
+	files[manifestJsonFileName] = []byte(`{}`)
+	files = map[string][]byte{manifestJsonFileName: files[manifestJsonFileName], signatureFileName: files[signatureFileName]}
+	tampered, err = createZipArchive(files)
+	if err != nil {
+		t.Fatalf("could not zip pass. %v", err)
+	}
+
+	if err := VerifyPassArchive(tampered); err == nil {
+		t.Errorf("Pass with a changed manifest should not be verified")
+	}
+}
+
+func TestVerifyPassArchive_Order(t *testing.T) {
+	template := NewInMemoryPassTemplate()
+	template.AddFileBytes("logo.png", []byte("logo"))
+
+	z, err := NewOrderSigner().CreateSignedAndZippedOrderArchive(getBasicOrder(), template, newTestSigningInformation(t))
+	if err != nil {
+		t.Fatalf("could not sign order. %v", err)
+	}
+
+	if err := VerifyPassArchive(z); err != nil {
+		t.Errorf("Signed order should be verified. %v", err)
+	}
+}
@@ -0,0 +1,246 @@
+package passkit
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	PassMimeType       = "application/vnd.apple.pkpass"
+	PassBundleMimeType = "application/vnd.apple.pkpasses"
+
+	authorizationScheme = "ApplePass "
+)
+
+// ErrPassNotFound is returned by a PassProvider when it has no pass with the requested identifiers.
+var ErrPassNotFound = errors.New("pass not found")
+
+// ServedPass is the latest version of a pass served by a WebService.
+type ServedPass struct {
+	Archive PassArchive
+	// AuthenticationToken is the token the devices must send, unused when the WebService has
+	// AuthenticationTokenSecrets.
+	AuthenticationToken string
+	LastModified        time.Time
+}
+
+// PassProvider returns the passes served by a WebService.
+type PassProvider interface {
+	// Pass returns the latest signed version of a pass, or ErrPassNotFound.
+	Pass(ctx context.Context, passTypeIdentifier, serialNumber string) (*ServedPass, error)
+}
+
+// WebServiceOption configures a WebService.
+type WebServiceOption func(s *WebService)
+
+// WithRegistrationStore sets where the device registrations are kept. By default they are kept in memory.
+func WithRegistrationStore(store RegistrationStore) WebServiceOption {
+	return func(s *WebService) {
+		s.registrations = store
+	}
+}
+
+// WithAuthenticationTokenSecrets checks the authentication tokens of the requests against the tokens derived by
+// secrets, instead of the AuthenticationToken of the served passes.
+func WithAuthenticationTokenSecrets(secrets *AuthenticationTokenSecrets) WebServiceOption {
+	return func(s *WebService) {
+		s.tokenSecrets = secrets
+	}
+}
+
+// WithLogHandler sets the function called with the messages devices send to the log endpoint, replacing the
+// structured device log handler.
+func WithLogHandler(handler func(logs []string)) WebServiceOption {
+	return func(s *WebService) {
+		s.logHandler = func(_ context.Context, entries []DeviceLogEntry) {
+			logs := make([]string, len(entries))
+			for i, e := range entries {
+				logs[i] = e.Message
+			}
+			handler(logs)
+		}
+	}
+}
+
+// WithDeviceLogHandler sets the function called with the categorized messages devices send to the log endpoint. By
+// default they are logged with the default slog handler, see SlogDeviceLogHandler.
+func WithDeviceLogHandler(handler func(ctx context.Context, entries []DeviceLogEntry)) WebServiceOption {
+	return func(s *WebService) {
+		s.logHandler = handler
+	}
+}
+
+// WebService is an http.Handler implementing the web service devices use to register for pass updates and to
+// download updated passes, as described in
+// https://developer.apple.com/documentation/walletpasses/adding-a-web-service-to-update-passes. It handles the
+// paths under /v1/, so the webServiceURL of the passes is the URL the handler is mounted at.
+type WebService struct {
+	passes        PassProvider
+	registrations RegistrationStore
+	tokenSecrets  *AuthenticationTokenSecrets
+	logHandler    func(ctx context.Context, entries []DeviceLogEntry)
+	mux           *http.ServeMux
+}
+
+// NewWebService creates a WebService serving the passes of provider.
+func NewWebService(provider PassProvider, opts ...WebServiceOption) *WebService {
+	s := &WebService{
+		passes:        provider,
+		registrations: NewMemoryRegistrationStore(),
+		logHandler:    SlogDeviceLogHandler(slog.Default().Handler()),
+		mux:           http.NewServeMux(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	s.mux.HandleFunc("POST /v1/devices/{deviceLibraryIdentifier}/registrations/{passTypeIdentifier}/{serialNumber}", s.register)
+	s.mux.HandleFunc("DELETE /v1/devices/{deviceLibraryIdentifier}/registrations/{passTypeIdentifier}/{serialNumber}", s.unregister)
+	s.mux.HandleFunc("GET /v1/devices/{deviceLibraryIdentifier}/registrations/{passTypeIdentifier}", s.updatedSerials)
+	s.mux.HandleFunc("GET /v1/passes/{passTypeIdentifier}/{serialNumber}", s.latestPass)
+	s.mux.HandleFunc("POST /v1/log", s.log)
+
+	return s
+}
+
+func (s *WebService) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// PassUpdated records that a pass changed, so the devices that registered it download it again the next time they
+// ask for updated passes.
+func (s *WebService) PassUpdated(ctx context.Context, passTypeIdentifier, serialNumber string) error {
+	return s.registrations.PassUpdated(ctx, passTypeIdentifier, serialNumber)
+}
+
+// authorize checks the authentication token of the request against the one of the pass. It writes the error
+// response and returns nil if the request is not authorized.
+func (s *WebService) authorize(w http.ResponseWriter, r *http.Request) *ServedPass {
+	p, err := s.passes.Pass(r.Context(), r.PathValue("passTypeIdentifier"), r.PathValue("serialNumber"))
+	if errors.Is(err, ErrPassNotFound) {
+		// Don't tell unauthenticated callers which passes exist
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return nil
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), authorizationScheme)
+	if !ok || !s.validToken(r.PathValue("passTypeIdentifier"), r.PathValue("serialNumber"), token, p) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return nil
+	}
+
+	return p
+}
+
+// validToken checks token in constant time. Empty tokens are never valid.
+func (s *WebService) validToken(passTypeIdentifier, serialNumber, token string, p *ServedPass) bool {
+	if token == "" {
+		return false
+	}
+
+	if s.tokenSecrets != nil {
+		return s.tokenSecrets.Verify(passTypeIdentifier, serialNumber, token)
+	}
+
+	if p.AuthenticationToken == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(p.AuthenticationToken)) == 1
+}
+
+func (s *WebService) register(w http.ResponseWriter, r *http.Request) {
+	if s.authorize(w, r) == nil {
+		return
+	}
+
+	var body struct {
+		PushToken string `json:"pushToken"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.PushToken == "" {
+		http.Error(w, "the pushToken is required", http.StatusBadRequest)
+		return
+	}
+
+	created, err := s.registrations.Register(r.Context(), r.PathValue("deviceLibraryIdentifier"), body.PushToken, r.PathValue("passTypeIdentifier"), r.PathValue("serialNumber"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if created {
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+func (s *WebService) unregister(w http.ResponseWriter, r *http.Request) {
+	if s.authorize(w, r) == nil {
+		return
+	}
+
+	if err := s.registrations.Unregister(r.Context(), r.PathValue("deviceLibraryIdentifier"), r.PathValue("passTypeIdentifier"), r.PathValue("serialNumber")); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (s *WebService) updatedSerials(w http.ResponseWriter, r *http.Request) {
+	serials, lastUpdated, err := s.registrations.UpdatedSerials(r.Context(), r.PathValue("deviceLibraryIdentifier"), r.PathValue("passTypeIdentifier"), r.URL.Query().Get("passesUpdatedSince"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if len(serials) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		SerialNumbers []string `json:"serialNumbers"`
+		LastUpdated   string   `json:"lastUpdated"`
+	}{serials, lastUpdated})
+}
+
+func (s *WebService) latestPass(w http.ResponseWriter, r *http.Request) {
+	p := s.authorize(w, r)
+	if p == nil {
+		return
+	}
+
+	ServePass(w, r, p.Archive, p.LastModified)
+}
+
+func (s *WebService) log(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Logs []string `json:"logs"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		http.Error(w, "invalid log request", http.StatusBadRequest)
+		return
+	}
+
+	entries := make([]DeviceLogEntry, len(body.Logs))
+	for i, l := range body.Logs {
+		entries[i] = ParseDeviceLog(l)
+	}
+
+	s.logHandler(r.Context(), entries)
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,150 @@
+package passkit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPassProvider map[passKey]*ServedPass
+
+func (p testPassProvider) Pass(_ context.Context, passTypeIdentifier, serialNumber string) (*ServedPass, error) {
+	if s, ok := p[passKey{passTypeIdentifier, serialNumber}]; ok {
+		return s, nil
+	}
+
+	return nil, ErrPassNotFound
+}
+
+func newTestWebService(opts ...WebServiceOption) *WebService {
+	return NewWebService(testPassProvider{
+		{"pass.test", "1"}: {Archive: PassArchive("archive"), AuthenticationToken: "token1234567890ab", LastModified: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{"pass.test", "2"}: {Archive: PassArchive("archive"), AuthenticationToken: "token1234567890ab", LastModified: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}, opts...)
+}
+
+func doWebServiceRequest(h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		r.Header.Set("Authorization", "ApplePass "+token)
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestWebService_Registration(t *testing.T) {
+	s := newTestWebService()
+	path := "/v1/devices/device1/registrations/pass.test/1"
+
+	if w := doWebServiceRequest(s, http.MethodPost, path, "wrong", `{"pushToken":"push"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("Registering with a wrong token should return 401, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodPost, "/v1/devices/device1/registrations/pass.test/3", "token1234567890ab", `{"pushToken":"push"}`); w.Code != http.StatusUnauthorized {
+		t.Errorf("Registering an unknown pass should return 401, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodPost, path, "token1234567890ab", `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Registering without a push token should return 400, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodPost, path, "token1234567890ab", `{"pushToken":"push"}`); w.Code != http.StatusCreated {
+		t.Errorf("Registering should return 201, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodPost, path, "token1234567890ab", `{"pushToken":"push"}`); w.Code != http.StatusOK {
+		t.Errorf("Registering again should return 200, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodDelete, path, "token1234567890ab", ""); w.Code != http.StatusOK {
+		t.Errorf("Unregistering should return 200, got %d", w.Code)
+	}
+
+	if w := doWebServiceRequest(s, http.MethodGet, "/v1/devices/device1/registrations/pass.test", "", ""); w.Code != http.StatusNoContent {
+		t.Errorf("Device without passes should return 204, got %d", w.Code)
+	}
+}
+
+func TestWebService_UpdatedSerials(t *testing.T) {
+	s := newTestWebService()
+	doWebServiceRequest(s, http.MethodPost, "/v1/devices/device1/registrations/pass.test/1", "token1234567890ab", `{"pushToken":"push"}`)
+	doWebServiceRequest(s, http.MethodPost, "/v1/devices/device1/registrations/pass.test/2", "token1234567890ab", `{"pushToken":"push"}`)
+
+	var resp struct {
+		SerialNumbers []string `json:"serialNumbers"`
+		LastUpdated   string   `json:"lastUpdated"`
+	}
+
+	w := doWebServiceRequest(s, http.MethodGet, "/v1/devices/device1/registrations/pass.test", "", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.SerialNumbers) != 2 {
+		t.Fatalf("Device should have 2 passes, got %s. %v", w.Body.String(), err)
+	}
+
+	if err := s.PassUpdated(context.Background(), "pass.test", "2"); err != nil {
+		t.Fatalf("could not update pass. %v", err)
+	}
+	w = doWebServiceRequest(s, http.MethodGet, "/v1/devices/device1/registrations/pass.test?passesUpdatedSince="+resp.LastUpdated, "", "")
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil || len(resp.SerialNumbers) != 1 || resp.SerialNumbers[0] != "2" {
+		t.Fatalf("Only the updated pass should be returned, got %s. %v", w.Body.String(), err)
+	}
+
+	w = doWebServiceRequest(s, http.MethodGet, "/v1/devices/device1/registrations/pass.test?passesUpdatedSince="+resp.LastUpdated, "", "")
+	if w.Code != http.StatusNoContent {
+		t.Errorf("No passes should be updated since the last tag, got %d", w.Code)
+	}
+}
+
+func TestWebService_LatestPass(t *testing.T) {
+	s := newTestWebService()
+
+	if w := doWebServiceRequest(s, http.MethodGet, "/v1/passes/pass.test/1", "", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Getting a pass without a token should return 401, got %d", w.Code)
+	}
+
+	w := doWebServiceRequest(s, http.MethodGet, "/v1/passes/pass.test/1", "token1234567890ab", "")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != PassMimeType || w.Body.String() != "archive" {
+		t.Errorf("Getting a pass should return it, got %d %q", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/passes/pass.test/1", nil)
+	r.Header.Set("Authorization", "ApplePass token1234567890ab")
+	r.Header.Set("If-Modified-Since", w.Header().Get("Last-Modified"))
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusNotModified {
+		t.Errorf("Getting a pass that didn't change should return 304, got %d", w.Code)
+	}
+}
+
+func TestWebService_EmptyToken(t *testing.T) {
+	s := NewWebService(testPassProvider{
+		{"pass.test", "1"}: {Archive: PassArchive("archive"), LastModified: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/passes/pass.test/1", nil)
+	r.Header.Set("Authorization", "ApplePass ")
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("An empty token should not match a pass without token, got %d", w.Code)
+	}
+}
+
+func TestWebService_Log(t *testing.T) {
+	var logs []string
+	s := newTestWebService(WithLogHandler(func(l []string) { logs = l }))
+
+	if w := doWebServiceRequest(s, http.MethodPost, "/v1/log", "", `{"logs":["a","b"]}`); w.Code != http.StatusOK {
+		t.Errorf("Logging should return 200, got %d", w.Code)
+	}
+
+	if len(logs) != 2 {
+		t.Errorf("Logs should be handed to the handler, got %v", logs)
+	}
+}